@@ -0,0 +1,222 @@
+package ksoftgo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultAlertInterval = 10 * time.Minute
+
+// Alert severities, least severe first.
+const (
+	SeverityAdvisory = "advisory"
+	SeverityWatch    = "watch"
+	SeverityWarning  = "warning"
+)
+
+// SeverityRank orders alert severities, higher is more severe. Unknown
+// severities rank lowest.
+func SeverityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case SeverityAdvisory:
+		return 1
+	case SeverityWatch:
+		return 2
+	case SeverityWarning:
+		return 3
+	}
+	return 0
+}
+
+// AlertSubscription is a place watched for weather alerts, either by
+// Location name or, when Location is empty, by coordinates.
+type AlertSubscription struct {
+	ID        string
+	Location  string
+	Latitude  float64
+	Longitude float64
+	Options   OptionalAdvWeather
+}
+
+type AlertEventType int
+
+const (
+	// AlertIssued is sent the first time an alert is seen.
+	AlertIssued AlertEventType = iota
+	// AlertExpired is sent when an alert passed its expiry or is no longer
+	// reported.
+	AlertExpired
+)
+
+type AlertEvent struct {
+	Type         AlertEventType
+	Subscription AlertSubscription
+	Alert        WeatherAlert
+}
+
+// AlertWatcher polls the weather of its subscriptions and reports alerts
+// as they are issued and expire.
+type AlertWatcher struct {
+	Session *KSession
+
+	// Interval is the time between two polls of all subscriptions.
+	Interval time.Duration
+	// MinSeverity drops alerts less severe than it, see SeverityRank.
+	MinSeverity string
+	// Handler receives the alert events.
+	Handler func(AlertEvent)
+	// OnError, if set, is called when polling a subscription fails.
+	OnError func(sub AlertSubscription, err error)
+
+	mu     sync.Mutex
+	subs   map[string]AlertSubscription
+	active map[string]map[string]WeatherAlert
+}
+
+// NewAlertWatcher creates a watcher polling every interval and sending
+// events to handler.
+func NewAlertWatcher(s *KSession, interval time.Duration, handler func(AlertEvent)) *AlertWatcher {
+	if interval <= 0 {
+		interval = defaultAlertInterval
+	}
+	return &AlertWatcher{
+		Session:  s,
+		Interval: interval,
+		Handler:  handler,
+		subs:     make(map[string]AlertSubscription),
+		active:   make(map[string]map[string]WeatherAlert),
+	}
+}
+
+// Subscribe starts watching sub, replacing a subscription with the same ID.
+func (w *AlertWatcher) Subscribe(sub AlertSubscription) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subs[sub.ID] = sub
+}
+
+// Unsubscribe stops watching a subscription and forgets its alerts.
+func (w *AlertWatcher) Unsubscribe(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.subs, id)
+	delete(w.active, id)
+}
+
+// Run polls all subscriptions every Interval until ctx is cancelled.
+func (w *AlertWatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.Poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks every subscription once.
+func (w *AlertWatcher) Poll(ctx context.Context) {
+	w.mu.Lock()
+	subs := make([]AlertSubscription, 0, len(w.subs))
+	for _, sub := range w.subs {
+		subs = append(subs, sub)
+	}
+	w.mu.Unlock()
+
+	for _, sub := range subs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		alerts, err := w.fetch(sub)
+		if err != nil {
+			if w.OnError != nil {
+				w.OnError(sub, err)
+			}
+			continue
+		}
+		for _, ev := range w.diff(sub, alerts, time.Now()) {
+			if w.Handler != nil {
+				w.Handler(ev)
+			}
+		}
+	}
+}
+
+func (w *AlertWatcher) fetch(sub AlertSubscription) ([]WeatherAlert, error) {
+	var weather Weather
+	var err error
+	if sub.Location != "" {
+		weather, err = w.Session.GetWeather(ParamWeather{
+			Location:   sub.Location,
+			ReportType: ReportCurrently,
+			Units:      sub.Options.Units,
+			Lang:       sub.Options.Lang,
+			Icons:      sub.Options.Icons,
+		})
+	} else {
+		weather, err = w.Session.GetAdvWeather(ParamAdvWeather{
+			Latitude:   sub.Latitude,
+			Longitude:  sub.Longitude,
+			ReportType: ReportCurrently,
+			Options:    sub.Options,
+		})
+	}
+	return weather.Data.Alerts, err
+}
+
+// diff updates the active alerts of sub and returns the resulting events.
+func (w *AlertWatcher) diff(sub AlertSubscription, alerts []WeatherAlert, now time.Time) (events []AlertEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.subs[sub.ID]; !ok {
+		return
+	}
+	active := w.active[sub.ID]
+	if active == nil {
+		active = make(map[string]WeatherAlert)
+		w.active[sub.ID] = active
+	}
+
+	current := make(map[string]struct{}, len(alerts))
+	for _, a := range alerts {
+		if SeverityRank(a.Severity) < SeverityRank(w.MinSeverity) {
+			continue
+		}
+		if !a.Expires.IsZero() && a.Expires.Before(now) {
+			continue
+		}
+
+		key := alertKey(a)
+		current[key] = struct{}{}
+		if _, ok := active[key]; !ok {
+			active[key] = a
+			events = append(events, AlertEvent{Type: AlertIssued, Subscription: sub, Alert: a})
+		}
+	}
+
+	for key, a := range active {
+		if _, ok := current[key]; !ok {
+			delete(active, key)
+			events = append(events, AlertEvent{Type: AlertExpired, Subscription: sub, Alert: a})
+		}
+	}
+	return
+}
+
+// alertKey identifies an alert by its URI and issue time, as the same URI
+// is reused when an alert is reissued.
+func alertKey(a WeatherAlert) string {
+	return a.URI + "@" + strconv.FormatInt(a.Time.Unix(), 10)
+}
@@ -0,0 +1,130 @@
+package ksoftgo
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// alertStep polls once with alerts and expects the events, written as
+// "issued:uri" or "expired:uri".
+type alertStep struct {
+	alerts []WeatherAlert
+	events []string
+}
+
+func TestAlertWatcherDiff(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	alert := func(uri, severity string, issued, expires time.Duration) WeatherAlert {
+		a := WeatherAlert{URI: uri, Severity: severity, Time: Timestamp{now.Add(issued)}}
+		if expires != 0 {
+			a.Expires = Timestamp{now.Add(expires)}
+		}
+		return a
+	}
+
+	storm := alert("storm", SeverityWarning, -time.Hour, time.Hour)
+	stormReissued := alert("storm", SeverityWarning, -time.Minute, 2*time.Hour)
+	fog := alert("fog", SeverityAdvisory, -time.Hour, 0)
+	expired := alert("heat", SeverityWarning, -2*time.Hour, -time.Minute)
+
+	tests := []struct {
+		name        string
+		minSeverity string
+		steps       []alertStep
+	}{
+		{
+			name: "issue once, expire when gone",
+			steps: []alertStep{
+				{[]WeatherAlert{storm, fog}, []string{"issued:fog", "issued:storm"}},
+				{[]WeatherAlert{storm, fog}, nil},
+				{[]WeatherAlert{storm}, []string{"expired:fog"}},
+				{nil, []string{"expired:storm"}},
+				{nil, nil},
+			},
+		},
+		{
+			name: "reissued alert is new",
+			steps: []alertStep{
+				{[]WeatherAlert{storm}, []string{"issued:storm"}},
+				{[]WeatherAlert{stormReissued}, []string{"expired:storm", "issued:storm"}},
+			},
+		},
+		{
+			name: "already expired alerts are ignored",
+			steps: []alertStep{
+				{[]WeatherAlert{expired}, nil},
+			},
+		},
+		{
+			name:        "minimum severity",
+			minSeverity: SeverityWatch,
+			steps: []alertStep{
+				{[]WeatherAlert{storm, fog}, []string{"issued:storm"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewAlertWatcher(nil, time.Minute, nil)
+			w.MinSeverity = tt.minSeverity
+			sub := AlertSubscription{ID: "sub"}
+			w.Subscribe(sub)
+
+			for i, step := range tt.steps {
+				var got []string
+				for _, ev := range w.diff(sub, step.alerts, now) {
+					kind := "issued:"
+					if ev.Type == AlertExpired {
+						kind = "expired:"
+					}
+					got = append(got, kind+ev.Alert.URI)
+				}
+				sort.Strings(got)
+				if strings.Join(got, ",") != strings.Join(step.events, ",") {
+					t.Errorf("step %d: events %q, want %q", i, got, step.events)
+				}
+			}
+		})
+	}
+}
+
+func TestAlertWatcherDiffUnsubscribed(t *testing.T) {
+	w := NewAlertWatcher(nil, time.Minute, nil)
+	sub := AlertSubscription{ID: "sub"}
+	alerts := []WeatherAlert{{URI: "storm", Severity: SeverityWarning}}
+
+	if events := w.diff(sub, alerts, time.Now()); len(events) != 0 {
+		t.Errorf("events for an unknown subscription: %+v", events)
+	}
+
+	w.Subscribe(sub)
+	w.diff(sub, alerts, time.Now())
+	w.Unsubscribe(sub.ID)
+	if events := w.diff(sub, nil, time.Now()); len(events) != 0 {
+		t.Errorf("events after unsubscribing: %+v", events)
+	}
+}
+
+func TestAlertWatcherPollSendsOptions(t *testing.T) {
+	s := testSession(func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		if q.Get("q") != "Montreal" || q.Get("units") != UnitsSI || q.Get("lang") != "fr" {
+			t.Errorf("request %s does not carry the subscription options", req.URL)
+		}
+		return jsonResponse(http.StatusOK, `{"data":{"alerts":[{"uri":"storm","severity":"warning","time":1704110400}]}}`), nil
+	})
+
+	var events []AlertEvent
+	w := NewAlertWatcher(s, time.Minute, func(ev AlertEvent) { events = append(events, ev) })
+	w.Subscribe(AlertSubscription{ID: "sub", Location: "Montreal", Options: OptionalAdvWeather{Units: UnitsSI, Lang: "fr"}})
+	w.Poll(context.Background())
+
+	if len(events) != 1 || events[0].Type != AlertIssued || events[0].Alert.URI != "storm" {
+		t.Errorf("events = %+v, want the storm issued", events)
+	}
+}
@@ -0,0 +1,152 @@
+package ksoftgo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Amount is an exact amount of money, counted in the minor unit of its
+// currency (cents for USD, yen for JPY, fils for KWD), so sums and
+// conversions never drift like float64 values do.
+type Amount struct {
+	Currency CurrencyCode
+	Minor    int64
+}
+
+// ParseAmount parses a decimal string such as "1.50" or "-3" into an amount
+// of currency, rounding half away from zero to the currency's minor unit.
+func ParseAmount(currency CurrencyCode, value string) (Amount, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	return amountFromRat(currency, r)
+}
+
+// AmountFromFloat converts a float64 into an amount of currency. The float
+// is read as the shortest decimal that represents it, so 0.1+0.2 becomes
+// 0.30 rather than 0.3000000000000000444.
+func AmountFromFloat(currency CurrencyCode, value float64) (Amount, error) {
+	return ParseAmount(currency, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func amountFromRat(currency CurrencyCode, r *big.Rat) (Amount, error) {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(minorScale(currency)))
+	minor := roundHalfAway(scaled)
+	if !minor.IsInt64() {
+		return Amount{}, fmt.Errorf("%w: %s out of range", ErrInvalidAmount, r.FloatString(currency.MinorUnits()))
+	}
+	return Amount{Currency: currency, Minor: minor.Int64()}, nil
+}
+
+func minorScale(currency CurrencyCode) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(currency.MinorUnits())), nil)
+}
+
+// roundHalfAway rounds r to the nearest integer, halves away from zero.
+func roundHalfAway(r *big.Rat) *big.Int {
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+	q, m := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(m, big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q
+}
+
+// Rat returns the amount as an exact rational number of major units.
+func (a Amount) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(a.Minor), minorScale(a.Currency))
+}
+
+// Decimal formats the amount with exactly the currency's number of
+// decimals, e.g. "1.50".
+func (a Amount) Decimal() string {
+	return a.Rat().FloatString(a.Currency.MinorUnits())
+}
+
+// Float64 returns the nearest float64, for APIs that only take floats.
+func (a Amount) Float64() float64 {
+	f, _ := a.Rat().Float64()
+	return f
+}
+
+// String formats the amount like Currency.Pretty, e.g. "1.50 USD".
+func (a Amount) String() string {
+	return a.Decimal() + " " + string(a.Currency)
+}
+
+// Add returns a+b. Both amounts must be in the same currency.
+func (a Amount) Add(b Amount) (Amount, error) {
+	if a.Currency != b.Currency {
+		return Amount{}, fmt.Errorf("adding %s to %s: currencies differ", b.Currency, a.Currency)
+	}
+	return Amount{Currency: a.Currency, Minor: a.Minor + b.Minor}, nil
+}
+
+// Sub returns a-b. Both amounts must be in the same currency.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if a.Currency != b.Currency {
+		return Amount{}, fmt.Errorf("subtracting %s from %s: currencies differ", b.Currency, a.Currency)
+	}
+	return Amount{Currency: a.Currency, Minor: a.Minor - b.Minor}, nil
+}
+
+// Convert converts the amount into another currency at rate units of to per
+// unit of a.Currency, rounding to the minor unit of to.
+func (a Amount) Convert(to CurrencyCode, rate float64) (Amount, error) {
+	if math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return Amount{}, fmt.Errorf("%w: rate %v", ErrInvalidAmount, rate)
+	}
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'g', -1, 64))
+	if !ok {
+		return Amount{}, fmt.Errorf("%w: rate %v", ErrInvalidAmount, rate)
+	}
+	return amountFromRat(to, r.Mul(r, a.Rat()))
+}
+
+// Amount returns the converted value as an exact amount of currency.
+func (c Currency) Amount(currency CurrencyCode) (Amount, error) {
+	return AmountFromFloat(currency, c.Value)
+}
+
+// Convert an exact amount into another currency
+// Example:
+//		amount, _ := ksoftgo.ParseAmount("USD", "1.50")
+//		converted, err := ksession.ConvertAmount(amount, "EUR")
+func (s *KSession) ConvertAmount(amount Amount, to CurrencyCode) (converted Amount, err error) {
+	curr, err := s.CurrencyConversion(ParamCurrency{
+		CurrencyFrom: amount.Currency,
+		CurrencyTo:   to,
+		Value:        amount.Float64(),
+	})
+	if err != nil {
+		return
+	}
+
+	to, _ = formatCurrencyCode(string(to))
+	return curr.Amount(to)
+}
+
+// ConvertAmount converts an exact amount with a known or freshly learned
+// exchange rate.
+func (t *RatesTable) ConvertAmount(amount Amount, to CurrencyCode) (Amount, error) {
+	to, err := formatCurrencyCode(string(to))
+	if err != nil {
+		return Amount{}, err
+	}
+	values, err := t.ConvertMany(amount.Currency, to, []float64{1})
+	if err != nil {
+		return Amount{}, err
+	}
+	return amount.Convert(to, values[0])
+}
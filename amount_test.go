@@ -0,0 +1,117 @@
+package ksoftgo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		currency CurrencyCode
+		value    string
+		want     string
+	}{
+		{"USD", "1.50", "1.50 USD"},
+		{"USD", "0.125", "0.13 USD"},
+		{"USD", "-0.125", "-0.13 USD"},
+		{"USD", "0.124", "0.12 USD"},
+		{"USD", " 3 ", "3.00 USD"},
+		{"JPY", "-2.5", "-3 JPY"},
+		{"JPY", "2.4", "2 JPY"},
+		{"KWD", "1.2345", "1.235 KWD"},
+		{"KWD", "1", "1.000 KWD"},
+		{"ZWG", "1.5", "1.50 ZWG"},
+	}
+
+	for _, tt := range tests {
+		amount, err := ParseAmount(tt.currency, tt.value)
+		if err != nil {
+			t.Errorf("ParseAmount(%s, %q): %v", tt.currency, tt.value, err)
+			continue
+		}
+		if got := amount.String(); got != tt.want {
+			t.Errorf("ParseAmount(%s, %q) = %s, want %s", tt.currency, tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "abc", "1,50", "1e400000000000"} {
+		if amount, err := ParseAmount("USD", value); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("ParseAmount(USD, %q) = %v, %v, want ErrInvalidAmount", value, amount, err)
+		}
+	}
+}
+
+func TestAmountFromFloat(t *testing.T) {
+	a, b := 0.1, 0.2
+	tests := []struct {
+		currency CurrencyCode
+		value    float64
+		want     string
+	}{
+		{"USD", a + b, "0.30 USD"},
+		{"USD", 1.005, "1.01 USD"},
+		{"JPY", -2.5, "-3 JPY"},
+		{"KWD", 0.0005, "0.001 KWD"},
+	}
+
+	for _, tt := range tests {
+		amount, err := AmountFromFloat(tt.currency, tt.value)
+		if err != nil {
+			t.Errorf("AmountFromFloat(%s, %v): %v", tt.currency, tt.value, err)
+			continue
+		}
+		if got := amount.String(); got != tt.want {
+			t.Errorf("AmountFromFloat(%s, %v) = %s, want %s", tt.currency, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAmountConvert(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		to     CurrencyCode
+		rate   float64
+		want   string
+	}{
+		{Amount{"USD", 150}, "EUR", 0.9, "1.35 EUR"},
+		{Amount{"USD", 100}, "JPY", 149.5, "150 JPY"},
+		{Amount{"JPY", 1000}, "KWD", 0.00205, "2.050 KWD"},
+		{Amount{"KWD", 1234}, "USD", 3.25, "4.01 USD"},
+		{Amount{"USD", -250}, "EUR", 0.5, "-1.25 EUR"},
+	}
+
+	for _, tt := range tests {
+		converted, err := tt.amount.Convert(tt.to, tt.rate)
+		if err != nil {
+			t.Errorf("%s.Convert(%s, %v): %v", tt.amount, tt.to, tt.rate, err)
+			continue
+		}
+		if got := converted.String(); got != tt.want {
+			t.Errorf("%s.Convert(%s, %v) = %s, want %s", tt.amount, tt.to, tt.rate, got, tt.want)
+		}
+	}
+
+	for _, rate := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if converted, err := (Amount{"USD", 100}).Convert("EUR", rate); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Convert(EUR, %v) = %v, %v, want ErrInvalidAmount", rate, converted, err)
+		}
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a := Amount{"USD", 10}
+	b := Amount{"USD", 20}
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "0.30 USD" {
+		t.Errorf("Add = %v, %v, want 0.30 USD", sum, err)
+	}
+	diff, err := a.Sub(b)
+	if err != nil || diff.String() != "-0.10 USD" {
+		t.Errorf("Sub = %v, %v, want -0.10 USD", diff, err)
+	}
+	if _, err = a.Add(Amount{"EUR", 1}); err == nil {
+		t.Error("Add of different currencies succeeded")
+	}
+}
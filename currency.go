@@ -0,0 +1,253 @@
+package ksoftgo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidCurrency = errors.New("invalid ISO 4217 currency code")
+	ErrNoRate          = errors.New("no exchange rate known")
+)
+
+const (
+	defaultRateTTL = time.Hour
+	// rateLearnValue is the amount converted to learn a rate. The API rounds
+	// its result to the minor unit, which would swamp the rate of a single
+	// unit of a weak currency.
+	rateLearnValue = 1000000
+)
+
+// CurrencyCode is an ISO 4217 currency code such as "EUR".
+type CurrencyCode string
+
+// ParseCurrencyCode upper-cases code and checks it is a known ISO 4217 code.
+func ParseCurrencyCode(code string) (CurrencyCode, error) {
+	c := CurrencyCode(strings.ToUpper(strings.TrimSpace(code)))
+	if !c.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+	return c, nil
+}
+
+// formatCurrencyCode upper-cases code and only checks it looks like an ISO
+// 4217 code, so currencies newer than currencyMinorUnits still reach the
+// API.
+func formatCurrencyCode(code string) (CurrencyCode, error) {
+	c := strings.ToUpper(strings.TrimSpace(code))
+	if len(c) != 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+		}
+	}
+	return CurrencyCode(c), nil
+}
+
+func (c CurrencyCode) upper() CurrencyCode {
+	return CurrencyCode(strings.ToUpper(strings.TrimSpace(string(c))))
+}
+
+// Valid reports whether c is a known ISO 4217 code.
+func (c CurrencyCode) Valid() bool {
+	_, ok := currencyMinorUnits[c]
+	return ok
+}
+
+// MinorUnits returns the number of decimals of the currency, 2 for unknown
+// currencies.
+func (c CurrencyCode) MinorUnits() int {
+	if n, ok := currencyMinorUnits[c]; ok {
+		return n
+	}
+	return 2
+}
+
+// currencyMinorUnits lists the active ISO 4217 currencies with the number
+// of digits of their minor unit.
+var currencyMinorUnits = map[CurrencyCode]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2, "AUD": 2,
+	"AWG": 2, "AZN": 2, "BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2, "BHD": 3, "BIF": 0,
+	"BMD": 2, "BND": 2, "BOB": 2, "BRL": 2, "BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2,
+	"BZD": 2, "CAD": 2, "CDF": 2, "CHF": 2, "CLP": 0, "CNY": 2, "COP": 2, "CRC": 2,
+	"CUP": 2, "CVE": 2, "CZK": 2, "DJF": 0, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2,
+	"ERN": 2, "ETB": 2, "EUR": 2, "FJD": 2, "FKP": 2, "GBP": 2, "GEL": 2, "GHS": 2,
+	"GIP": 2, "GMD": 2, "GNF": 0, "GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2, "HTG": 2,
+	"HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "IQD": 3, "IRR": 2, "ISK": 0, "JMD": 2,
+	"JOD": 3, "JPY": 0, "KES": 2, "KGS": 2, "KHR": 2, "KMF": 0, "KPW": 2, "KRW": 0,
+	"KWD": 3, "KYD": 2, "KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2, "LSL": 2,
+	"LYD": 3, "MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2, "MNT": 2, "MOP": 2,
+	"MRU": 2, "MUR": 2, "MVR": 2, "MWK": 2, "MXN": 2, "MYR": 2, "MZN": 2, "NAD": 2,
+	"NGN": 2, "NIO": 2, "NOK": 2, "NPR": 2, "NZD": 2, "OMR": 3, "PAB": 2, "PEN": 2,
+	"PGK": 2, "PHP": 2, "PKR": 2, "PLN": 2, "PYG": 0, "QAR": 2, "RON": 2, "RSD": 2,
+	"RUB": 2, "RWF": 0, "SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2,
+	"SHP": 2, "SLE": 2, "SOS": 2, "SRD": 2, "SSP": 2, "STN": 2, "SYP": 2, "SZL": 2,
+	"THB": 2, "TJS": 2, "TMT": 2, "TND": 3, "TOP": 2, "TRY": 2, "TTD": 2, "TWD": 2,
+	"TZS": 2, "UAH": 2, "UGX": 0, "USD": 2, "UYU": 2, "UZS": 2, "VES": 2, "VND": 0,
+	"VUV": 0, "WST": 2, "XAF": 0, "XCD": 2, "XOF": 0, "XPF": 0, "YER": 2, "ZAR": 2,
+	"ZMW": 2, "ZWL": 2,
+}
+
+// RatesTable learns exchange rates from CurrencyConversion responses and
+// serves later conversions between the same currencies, or currencies
+// linked through a common one, without another request while the rates are
+// younger than TTL.
+type RatesTable struct {
+	Session *KSession
+
+	// TTL is how long a learned rate is used.
+	TTL time.Duration
+
+	mu    sync.Mutex
+	rates map[currencyPair]rateEntry
+}
+
+type currencyPair struct {
+	from, to CurrencyCode
+}
+
+type rateEntry struct {
+	rate    float64
+	learned time.Time
+}
+
+// NewRatesTable creates a rates table trusting learned rates for ttl.
+func NewRatesTable(s *KSession, ttl time.Duration) *RatesTable {
+	if ttl <= 0 {
+		ttl = defaultRateTTL
+	}
+	return &RatesTable{Session: s, TTL: ttl}
+}
+
+// Learn records the rate implied by a conversion of value and its result.
+func (t *RatesTable) Learn(param ParamCurrency, result Currency) {
+	if param.Value == 0 || result.Value == 0 {
+		return
+	}
+	t.SetRate(param.CurrencyFrom, param.CurrencyTo, result.Value/param.Value)
+}
+
+// SetRate records that one unit of from is worth rate units of to.
+func (t *RatesTable) SetRate(from, to CurrencyCode, rate float64) {
+	from, to = from.upper(), to.upper()
+	if rate <= 0 || from == to {
+		return
+	}
+
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.rates == nil {
+		t.rates = make(map[currencyPair]rateEntry)
+	}
+	t.rates[currencyPair{from, to}] = rateEntry{rate, now}
+	t.rates[currencyPair{to, from}] = rateEntry{1 / rate, now}
+}
+
+// Rate returns the known rate from one currency to another, directly or
+// through a third currency. Of several third currencies the one whose rates
+// were learned most recently is used.
+func (t *RatesTable) Rate(from, to CurrencyCode) (float64, bool) {
+	from, to = from.upper(), to.upper()
+	if from == to {
+		return 1, true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if r, ok := t.fresh(currencyPair{from, to}, now); ok {
+		return r, true
+	}
+
+	var best struct {
+		via     CurrencyCode
+		rate    float64
+		learned time.Time
+	}
+	for pair, first := range t.rates {
+		if pair.from != from || now.Sub(first.learned) >= t.ttl() {
+			continue
+		}
+		second, ok := t.rates[currencyPair{pair.to, to}]
+		if !ok || now.Sub(second.learned) >= t.ttl() {
+			continue
+		}
+
+		learned := first.learned
+		if second.learned.Before(learned) {
+			learned = second.learned
+		}
+		if best.via == "" || learned.After(best.learned) ||
+			learned.Equal(best.learned) && pair.to < best.via {
+			best.via, best.rate, best.learned = pair.to, first.rate*second.rate, learned
+		}
+	}
+	return best.rate, best.via != ""
+}
+
+// Convert converts amount, asking the API only when no rate is known.
+// Example:
+//		value, err := rates.Convert("USD", "EUR", 1.50)
+func (t *RatesTable) Convert(from, to CurrencyCode, amount float64) (float64, error) {
+	values, err := t.ConvertMany(from, to, []float64{amount})
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
+
+// ConvertMany converts every amount with at most one request. Like
+// CurrencyConversion it accepts any three letter code.
+func (t *RatesTable) ConvertMany(from, to CurrencyCode, amounts []float64) ([]float64, error) {
+	from, err := formatCurrencyCode(string(from))
+	if err != nil {
+		return nil, err
+	}
+	if to, err = formatCurrencyCode(string(to)); err != nil {
+		return nil, err
+	}
+
+	rate, ok := t.Rate(from, to)
+	if !ok {
+		param := ParamCurrency{CurrencyFrom: from, CurrencyTo: to, Value: rateLearnValue}
+		result, err := t.Session.CurrencyConversion(param)
+		if err != nil {
+			return nil, err
+		}
+		t.Learn(param, result)
+		if rate, ok = t.Rate(from, to); !ok {
+			return nil, fmt.Errorf("%w: %s to %s", ErrNoRate, from, to)
+		}
+	}
+
+	values := make([]float64, len(amounts))
+	for i, a := range amounts {
+		values[i] = a * rate
+	}
+	return values, nil
+}
+
+// fresh returns the rate of pair if it is younger than the TTL. t.mu must
+// be held.
+func (t *RatesTable) fresh(pair currencyPair, now time.Time) (float64, bool) {
+	entry, ok := t.rates[pair]
+	if !ok || now.Sub(entry.learned) >= t.ttl() {
+		return 0, false
+	}
+	return entry.rate, true
+}
+
+func (t *RatesTable) ttl() time.Duration {
+	if t.TTL <= 0 {
+		return defaultRateTTL
+	}
+	return t.TTL
+}
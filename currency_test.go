@@ -0,0 +1,183 @@
+package ksoftgo
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseCurrencyCode(t *testing.T) {
+	tests := []struct {
+		code   string
+		parsed CurrencyCode
+		strict bool
+		format bool
+	}{
+		{"USD", "USD", true, true},
+		{" eur ", "EUR", true, true},
+		{"ZWG", "ZWG", false, true},
+		{"ved", "VED", false, true},
+		{"US", "", false, false},
+		{"USDT", "", false, false},
+		{"U$D", "", false, false},
+		{"", "", false, false},
+	}
+
+	for _, tt := range tests {
+		code, err := ParseCurrencyCode(tt.code)
+		if tt.strict && (err != nil || code != tt.parsed) {
+			t.Errorf("ParseCurrencyCode(%q) = %q, %v, want %q", tt.code, code, err, tt.parsed)
+		}
+		if !tt.strict && !errors.Is(err, ErrInvalidCurrency) {
+			t.Errorf("ParseCurrencyCode(%q) = %q, %v, want ErrInvalidCurrency", tt.code, code, err)
+		}
+
+		code, err = formatCurrencyCode(tt.code)
+		if tt.format && (err != nil || code != tt.parsed) {
+			t.Errorf("formatCurrencyCode(%q) = %q, %v, want %q", tt.code, code, err, tt.parsed)
+		}
+		if !tt.format && !errors.Is(err, ErrInvalidCurrency) {
+			t.Errorf("formatCurrencyCode(%q) = %q, %v, want ErrInvalidCurrency", tt.code, code, err)
+		}
+	}
+}
+
+func TestRatesTableRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(r *RatesTable)
+		from, to CurrencyCode
+		want     float64
+		ok       bool
+	}{
+		{
+			name:  "same currency",
+			setup: func(r *RatesTable) {},
+			from:  "USD", to: "USD",
+			want: 1, ok: true,
+		},
+		{
+			name:  "direct",
+			setup: func(r *RatesTable) { r.SetRate("USD", "EUR", 0.9) },
+			from:  "USD", to: "EUR",
+			want: 0.9, ok: true,
+		},
+		{
+			name:  "inverse",
+			setup: func(r *RatesTable) { r.SetRate("USD", "EUR", 0.8) },
+			from:  "EUR", to: "USD",
+			want: 1.25, ok: true,
+		},
+		{
+			name:  "lower case codes",
+			setup: func(r *RatesTable) { r.SetRate("usd", "eur", 0.8) },
+			from:  "EUR", to: "usd",
+			want: 1.25, ok: true,
+		},
+		{
+			name: "cross rate",
+			setup: func(r *RatesTable) {
+				r.SetRate("USD", "EUR", 0.9)
+				r.SetRate("USD", "JPY", 150)
+			},
+			from: "EUR", to: "JPY",
+			want: 150 / 0.9, ok: true,
+		},
+		{
+			name:  "unknown",
+			setup: func(r *RatesTable) { r.SetRate("USD", "EUR", 0.9) },
+			from:  "GBP", to: "JPY",
+		},
+		{
+			name: "expired",
+			setup: func(r *RatesTable) {
+				r.SetRate("USD", "EUR", 0.9)
+				for pair, entry := range r.rates {
+					entry.learned = time.Now().Add(-2 * time.Hour)
+					r.rates[pair] = entry
+				}
+			},
+			from: "USD", to: "EUR",
+		},
+		{
+			name:  "invalid rates are ignored",
+			setup: func(r *RatesTable) { r.SetRate("USD", "EUR", -1) },
+			from:  "USD", to: "EUR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRatesTable(nil, time.Hour)
+			tt.setup(r)
+
+			got, ok := r.Rate(tt.from, tt.to)
+			if ok != tt.ok || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Rate(%s, %s) = %v, %v, want %v, %v", tt.from, tt.to, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRatesTableCrossRateDeterministic(t *testing.T) {
+	r := NewRatesTable(nil, time.Hour)
+	r.SetRate("EUR", "USD", 1.1)
+	r.SetRate("USD", "JPY", 150)
+	r.SetRate("EUR", "GBP", 0.85)
+	r.SetRate("GBP", "JPY", 190)
+
+	// Make the path through GBP older than the one through USD.
+	r.mu.Lock()
+	for pair, entry := range r.rates {
+		if pair.from == "GBP" || pair.to == "GBP" {
+			entry.learned = entry.learned.Add(-time.Minute)
+			r.rates[pair] = entry
+		}
+	}
+	r.mu.Unlock()
+
+	for i := 0; i < 50; i++ {
+		if got, ok := r.Rate("EUR", "JPY"); !ok || math.Abs(got-1.1*150) > 1e-9 {
+			t.Fatalf("Rate(EUR, JPY) = %v, %v, want %v through USD", got, ok, 1.1*150)
+		}
+	}
+}
+
+func TestRatesTableConvertMany(t *testing.T) {
+	var requests int32
+	s := testSession(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+		q := req.URL.Query()
+		if q.Get("from") != "ZWG" || q.Get("to") != "USD" {
+			t.Errorf("request %s, want ZWG to USD", req.URL)
+		}
+		value, _ := strconv.ParseFloat(q.Get("value"), 64)
+		// The API rounds to cents, like its pretty value.
+		result := math.Round(value*0.0037*100) / 100
+		return jsonResponse(http.StatusOK, `{"value":`+strconv.FormatFloat(result, 'f', -1, 64)+`}`), nil
+	})
+	r := NewRatesTable(s, time.Hour)
+
+	values, err := r.ConvertMany("zwg", "usd", []float64{1, 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(values[0]-0.0037) > 1e-9 || math.Abs(values[1]-3.7) > 1e-9 {
+		t.Errorf("ConvertMany = %v, want [0.0037 3.7]", values)
+	}
+
+	if _, err = r.Convert("ZWG", "USD", 5); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+
+	if _, err = r.Convert("ZW", "USD", 5); !errors.Is(err, ErrInvalidCurrency) {
+		t.Errorf("Convert(ZW) = %v, want ErrInvalidCurrency", err)
+	}
+}
@@ -1,12 +1,17 @@
 package ksoftgo
 
 import (
+	"errors"
 	"fmt"
 	"github.com/google/go-querystring/query"
 	"net/url"
 	"strconv"
 )
 
+// ErrInvalidPathSegment is returned when a value that ends up in the URL path
+// of a request contains characters the API does not allow.
+var ErrInvalidPathSegment = errors.New("invalid path segment")
+
 var (
 	EndpointRest = "https://api.ksoft.si/"
 
@@ -15,7 +20,7 @@ var (
 	EndpointMemeRandomMeme  = EndpointRest + "images/random-meme"
 	EndpointMemeTags        = EndpointRest + "images/tags"
 	EndpointMemeRandomAww   = EndpointRest + "images/random-aww"
-	EndpointMemeImage       = func(snowflake string) string { return EndpointRest + "images/image/" + snowflake }
+	EndpointMemeImage       = func(snowflake string) string { return EndpointRest + "images/image/" + escapeSegment(snowflake) }
 	EndpointMemeRandomImage = func(param ParamRandomImage) string {
 		q, _ := query.Values(param)
 		return EndpointRest + "images/random-image?" + q.Encode()
@@ -26,7 +31,7 @@ var (
 	}
 	EndpointMemeRandomReddit = func(param ParamRandomReddit) string {
 		q, _ := query.Values(param.Options)
-		return EndpointRest + "images/rand-reddit/" + param.SubReddit + "?" + q.Encode()
+		return EndpointRest + "images/rand-reddit/" + escapeSegment(param.SubReddit) + "?" + q.Encode()
 	}
 	EndpointMemeRandomNSFW = func(param ParamRandomNSFW) string {
 		q, _ := query.Values(param)
@@ -56,17 +61,19 @@ var (
 	// --------- KUMO ENDPOINTS ------------------------------------------------
 
 	EndpointKumoGis = func(param ParamGIS) string {
-		return EndpointRest + "kumo/gis?q=" + url.QueryEscape(param.Location)
+		q, _ := query.Values(param)
+		return EndpointRest + "kumo/gis?" + q.Encode()
 	}
 	EndpointKumoWeather = func(param ParamWeather) string {
-		return EndpointRest + "kumo/weather/" + param.ReportType + "?q=" + url.QueryEscape(param.Location)
+		q, _ := query.Values(param)
+		return EndpointRest + "kumo/weather/" + escapeSegment(param.ReportType) + "?" + q.Encode()
 	}
 	EndpointKumoWeatherAdv = func(param ParamAdvWeather) string {
 		q, _ := query.Values(param.Options)
 		return EndpointRest + fmt.Sprintf("kumo/weather/%s,%s/%s?%s",
 			strconv.FormatFloat(param.Latitude, 'f', -1, 64),
 			strconv.FormatFloat(param.Longitude, 'f', -1, 64),
-			param.ReportType, q.Encode())
+			escapeSegment(param.ReportType), q.Encode())
 	}
 	EndpointKumoGeoIP = func(param ParamIP) string {
 		q, _ := query.Values(param)
@@ -88,3 +95,33 @@ var (
 	EndpointLyricsTrack          = func(id int64) string { return EndpointRest + "lyrics/track/" + strconv.FormatInt(id, 10) }
 	EndpointMusicRecommendations = EndpointRest + "music/recommendations"
 )
+
+// escapeSegment escapes seg so it stays a single path segment, including
+// the "." and ".." segments url.PathEscape leaves alone.
+func escapeSegment(seg string) string {
+	switch seg {
+	case ".":
+		return "%2E"
+	case "..":
+		return "%2E%2E"
+	}
+	return url.PathEscape(seg)
+}
+
+// checkPathSegment makes sure a value can be used as a single path segment.
+// Only letters, digits, '-', '_' and '.' are allowed, and "." and ".." are
+// rejected so a value can never point the request at another route.
+func checkPathSegment(seg string) error {
+	if seg == "" || seg == "." || seg == ".." {
+		return fmt.Errorf("%w: %q", ErrInvalidPathSegment, seg)
+	}
+	for _, c := range seg {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.':
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidPathSegment, seg)
+		}
+	}
+	return nil
+}
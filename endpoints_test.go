@@ -0,0 +1,336 @@
+package ksoftgo
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// parseEndpoint parses an endpoint URL and checks it stays on the API host
+// without a fragment.
+func parseEndpoint(t *testing.T, raw string) *url.URL {
+	t.Helper()
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("url.Parse(%q): %v", raw, err)
+	}
+	if u.Scheme != "https" || u.Host != "api.ksoft.si" {
+		t.Fatalf("%q: unexpected scheme or host", raw)
+	}
+	if u.Fragment != "" || u.RawFragment != "" || strings.Contains(raw, "#") {
+		t.Fatalf("%q: unexpected fragment", raw)
+	}
+	return u
+}
+
+// pathSegments checks the path of u starts with prefix and returns the
+// unescaped segments after it.
+func pathSegments(t *testing.T, u *url.URL, prefix string) []string {
+	t.Helper()
+
+	escaped := u.EscapedPath()
+	if !strings.HasPrefix(escaped, "/"+prefix) {
+		t.Fatalf("%q: path not under %q", u, prefix)
+	}
+
+	var segments []string
+	for _, seg := range strings.Split(strings.TrimPrefix(escaped, "/"+prefix), "/") {
+		unescaped, err := url.PathUnescape(seg)
+		if err != nil {
+			t.Fatalf("%q: %v", u, err)
+		}
+		segments = append(segments, unescaped)
+	}
+	return segments
+}
+
+// queryValues checks the query of u only has the allowed keys.
+func queryValues(t *testing.T, u *url.URL, allowed ...string) url.Values {
+	t.Helper()
+
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		t.Fatalf("%q: %v", u, err)
+	}
+	for key := range values {
+		found := false
+		for _, a := range allowed {
+			found = found || key == a
+		}
+		if !found {
+			t.Fatalf("%q: unexpected query parameter %q", u, key)
+		}
+	}
+	return values
+}
+
+// checkSegment checks a path built from a single value is exactly that value.
+func checkSegment(t *testing.T, u *url.URL, prefix, want string) {
+	t.Helper()
+
+	segments := pathSegments(t, u, prefix)
+	if len(segments) != 1 || segments[0] != want {
+		t.Fatalf("%q: path segments %q, want [%q]", u, segments, want)
+	}
+}
+
+func checkQuery(t *testing.T, values url.Values, key, want string) {
+	t.Helper()
+
+	if got := values.Get(key); got != want {
+		t.Fatalf("query %q = %q, want %q", key, got, want)
+	}
+}
+
+var segmentSeeds = []string{"i-ix63ra_m-12", "memes", "", ".", "..", "../bans/list", "a/b", "a?b", "a#b", "%2F", "a b", "\x00", "é"}
+
+func FuzzEndpointMemeImage(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, snowflake string) {
+		u := parseEndpoint(t, EndpointMemeImage(snowflake))
+		checkSegment(t, u, "images/image/", snowflake)
+		if u.RawQuery != "" || u.ForceQuery {
+			t.Fatalf("%q: unexpected query", u)
+		}
+	})
+}
+
+func FuzzEndpointMemeRandomImage(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s, false)
+	}
+	f.Fuzz(func(t *testing.T, tag string, nsfw bool) {
+		u := parseEndpoint(t, EndpointMemeRandomImage(ParamRandomImage{Tag: tag, NSFW: Bool(nsfw)}))
+		checkSegment(t, u, "images/", "random-image")
+		values := queryValues(t, u, "tag", "nsfw")
+		checkQuery(t, values, "tag", tag)
+		checkQuery(t, values, "nsfw", strconv.FormatBool(nsfw))
+	})
+}
+
+func FuzzEndpointMemeWikihow(f *testing.F) {
+	f.Add(true)
+	f.Fuzz(func(t *testing.T, nsfw bool) {
+		u := parseEndpoint(t, EndpointMemeWikihow(ParamWikiHow{NSFW: Bool(nsfw)}))
+		checkSegment(t, u, "images/", "random-wikihow")
+		checkQuery(t, queryValues(t, u, "nsfw"), "nsfw", strconv.FormatBool(nsfw))
+	})
+}
+
+func FuzzEndpointMemeRandomReddit(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s, "month", true)
+		f.Add("memes", s, false)
+	}
+	f.Fuzz(func(t *testing.T, subreddit, span string, removeNSFW bool) {
+		u := parseEndpoint(t, EndpointMemeRandomReddit(ParamRandomReddit{
+			SubReddit: subreddit,
+			Options:   OptionalRandomReddit{RemoveNSFW: Bool(removeNSFW), Span: Span(span)},
+		}))
+		checkSegment(t, u, "images/rand-reddit/", subreddit)
+		values := queryValues(t, u, "remove_nsfw", "span")
+		checkQuery(t, values, "remove_nsfw", strconv.FormatBool(removeNSFW))
+		checkQuery(t, values, "span", span)
+	})
+}
+
+func FuzzEndpointMemeRandomNSFW(f *testing.F) {
+	f.Add(true)
+	f.Fuzz(func(t *testing.T, gifs bool) {
+		u := parseEndpoint(t, EndpointMemeRandomNSFW(ParamRandomNSFW{GIFsOnly: Bool(gifs)}))
+		checkSegment(t, u, "images/", "random-nsfw")
+		checkQuery(t, queryValues(t, u, "gifs"), "gifs", strconv.FormatBool(gifs))
+	})
+}
+
+func FuzzEndpointBansInfo(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, user string) {
+		u := parseEndpoint(t, EndpointBansInfo(ParamBans{UserID: user}))
+		checkSegment(t, u, "bans/", "info")
+		checkQuery(t, queryValues(t, u, "user"), "user", user)
+	})
+}
+
+func FuzzEndpointBansCheck(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, user string) {
+		u := parseEndpoint(t, EndpointBansCheck(ParamBans{UserID: user}))
+		checkSegment(t, u, "bans/", "check")
+		checkQuery(t, queryValues(t, u, "user"), "user", user)
+	})
+}
+
+func FuzzEndpointBansDelete(f *testing.F) {
+	f.Add(int64(123456789123456789), true)
+	f.Fuzz(func(t *testing.T, user int64, force bool) {
+		u := parseEndpoint(t, EndpointBansDelete(ParamDeleteBan{User: user, Force: Bool(force)}))
+		checkSegment(t, u, "bans/", "delete")
+		values := queryValues(t, u, "user", "force")
+		checkQuery(t, values, "user", strconv.FormatInt(user, 10))
+		checkQuery(t, values, "force", strconv.FormatBool(force))
+	})
+}
+
+func FuzzEndpointBansList(f *testing.F) {
+	f.Add(int64(1), 20)
+	f.Fuzz(func(t *testing.T, page int64, perPage int) {
+		u := parseEndpoint(t, EndpointBansList(ParamListBans{Page: Int64(page), PerPage: Int(perPage)}))
+		checkSegment(t, u, "bans/", "list")
+		values := queryValues(t, u, "page", "per_page")
+		checkQuery(t, values, "page", strconv.FormatInt(page, 10))
+		checkQuery(t, values, "per_page", strconv.Itoa(perPage))
+	})
+}
+
+func FuzzEndpointKumoGis(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s, 12)
+	}
+	f.Fuzz(func(t *testing.T, location string, zoom int) {
+		u := parseEndpoint(t, EndpointKumoGis(ParamGIS{Location: location, MapZoom: Int(zoom)}))
+		checkSegment(t, u, "kumo/", "gis")
+		values := queryValues(t, u, "q", "fast", "more", "map_zoom", "include_map")
+		checkQuery(t, values, "q", location)
+		checkQuery(t, values, "map_zoom", strconv.Itoa(zoom))
+	})
+}
+
+func FuzzEndpointKumoWeather(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s, "Montreal", "si")
+		f.Add("currently", s, "")
+	}
+	f.Fuzz(func(t *testing.T, reportType, location, units string) {
+		u := parseEndpoint(t, EndpointKumoWeather(ParamWeather{Location: location, ReportType: reportType, Units: units}))
+		checkSegment(t, u, "kumo/weather/", reportType)
+		values := queryValues(t, u, "q", "units", "lang", "icons")
+		checkQuery(t, values, "q", location)
+		checkQuery(t, values, "units", units)
+	})
+}
+
+func FuzzEndpointKumoWeatherAdv(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(45.5, -73.6, s, "si")
+		f.Add(0.0, 0.0, "currently", s)
+	}
+	f.Fuzz(func(t *testing.T, lat, lon float64, reportType, units string) {
+		u := parseEndpoint(t, EndpointKumoWeatherAdv(ParamAdvWeather{
+			Latitude:   lat,
+			Longitude:  lon,
+			ReportType: reportType,
+			Options:    OptionalAdvWeather{Units: units},
+		}))
+		segments := pathSegments(t, u, "kumo/weather/")
+		coords := strconv.FormatFloat(lat, 'f', -1, 64) + "," + strconv.FormatFloat(lon, 'f', -1, 64)
+		if len(segments) != 2 || segments[0] != coords || segments[1] != reportType {
+			t.Fatalf("%q: path segments %q, want [%q %q]", u, segments, coords, reportType)
+		}
+		checkQuery(t, queryValues(t, u, "units", "lang", "icons"), "units", units)
+	})
+}
+
+func FuzzEndpointKumoGeoIP(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, ip string) {
+		u := parseEndpoint(t, EndpointKumoGeoIP(ParamIP{IP: ip}))
+		checkSegment(t, u, "kumo/", "geoip")
+		checkQuery(t, queryValues(t, u, "ip"), "ip", ip)
+	})
+}
+
+func FuzzEndpointKumoCurrency(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s, "EUR", 1.5)
+	}
+	f.Fuzz(func(t *testing.T, from, to string, value float64) {
+		u := parseEndpoint(t, EndpointKumoCurrency(ParamCurrency{
+			CurrencyFrom: CurrencyCode(from),
+			CurrencyTo:   CurrencyCode(to),
+			Value:        value,
+		}))
+		checkSegment(t, u, "kumo/", "currency")
+		values := queryValues(t, u, "from", "to", "value")
+		checkQuery(t, values, "from", from)
+		checkQuery(t, values, "to", to)
+	})
+}
+
+func FuzzEndpointLyricsSearch(f *testing.F) {
+	for _, s := range segmentSeeds {
+		f.Add(s, 10)
+	}
+	f.Fuzz(func(t *testing.T, q string, limit int) {
+		u := parseEndpoint(t, EndpointLyricsSearch(ParamSearchLyrics{Query: q, Limit: Int(limit)}))
+		checkSegment(t, u, "lyrics/", "search")
+		values := queryValues(t, u, "q", "text_only", "limit")
+		checkQuery(t, values, "q", q)
+		checkQuery(t, values, "limit", strconv.Itoa(limit))
+	})
+}
+
+func fuzzLyricsID(f *testing.F, endpoint func(int64) string, prefix string) {
+	f.Add(int64(628942))
+	f.Add(int64(-1))
+	f.Fuzz(func(t *testing.T, id int64) {
+		u := parseEndpoint(t, endpoint(id))
+		checkSegment(t, u, prefix, strconv.FormatInt(id, 10))
+		if u.RawQuery != "" {
+			t.Fatalf("%q: unexpected query", u)
+		}
+	})
+}
+
+func FuzzEndpointLyricsArtist(f *testing.F) {
+	fuzzLyricsID(f, EndpointLyricsArtist, "lyrics/artist/")
+}
+
+func FuzzEndpointLyricsAlbum(f *testing.F) {
+	fuzzLyricsID(f, EndpointLyricsAlbum, "lyrics/album/")
+}
+
+func FuzzEndpointLyricsTrack(f *testing.F) {
+	fuzzLyricsID(f, EndpointLyricsTrack, "lyrics/track/")
+}
+
+func TestCheckPathSegment(t *testing.T) {
+	tests := []struct {
+		seg   string
+		valid bool
+	}{
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"a/b", false},
+		{"a?b", false},
+		{"a#b", false},
+		{"a%2Fb", false},
+		{"a b", false},
+		{"i-ix63ra_m-12", true},
+		{"memes", true},
+		{"currently", true},
+		{"a.b", true},
+	}
+
+	for _, tt := range tests {
+		err := checkPathSegment(tt.seg)
+		if tt.valid && err != nil {
+			t.Errorf("checkPathSegment(%q) = %v, want nil", tt.seg, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidPathSegment) {
+			t.Errorf("checkPathSegment(%q) = %v, want ErrInvalidPathSegment", tt.seg, err)
+		}
+	}
+}
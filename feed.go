@@ -0,0 +1,210 @@
+package ksoftgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNoFreshItem is returned by a Feed when every attempt returned an item
+// that was already served for the key.
+var ErrNoFreshItem = errors.New("no unseen item found")
+
+const (
+	defaultFeedHistory  = 100
+	defaultFeedAttempts = 5
+)
+
+// FeedStore persists the history of a Feed so it survives restarts.
+type FeedStore interface {
+	// Load returns the remembered identifiers for key, oldest first.
+	Load(key string) ([]string, error)
+	// Save replaces the remembered identifiers for key, oldest first.
+	Save(key string, ids []string) error
+}
+
+// Feed serves random posts and images without repeating the ones recently
+// served for the same key, usually a channel or guild ID. Posts are
+// identified by their Source URL, images by their snowflake.
+type Feed struct {
+	Session *KSession
+
+	// HistorySize is how many identifiers are remembered per key.
+	HistorySize int
+	// MaxAttempts is how many times the API is asked for a fresh item
+	// before ErrNoFreshItem is returned.
+	MaxAttempts int
+	// Store optionally persists the history.
+	Store FeedStore
+	// Fingerprints optionally skips items that look like an item already
+	// served for the key under another URL. Media that cannot be downloaded
+	// or decoded is treated as new.
+	Fingerprints *Fingerprinter
+
+	mu      sync.Mutex
+	history map[string]*feedHistory
+}
+
+type feedHistory struct {
+	ids  []string
+	seen map[string]struct{}
+}
+
+// NewFeed creates a deduplicating feed remembering historySize items per key.
+func NewFeed(s *KSession, historySize int) *Feed {
+	if historySize <= 0 {
+		historySize = defaultFeedHistory
+	}
+	return &Feed{
+		Session:     s,
+		HistorySize: historySize,
+		MaxAttempts: defaultFeedAttempts,
+	}
+}
+
+// RandomMeme returns a random meme not yet served for key.
+func (f *Feed) RandomMeme(key string) (r Reddit, err error) {
+	err = f.next(key, func() (string, MediaSource, error) {
+		var err error
+		r, err = f.Session.RandomMeme()
+		return redditID(r), r, err
+	})
+	return
+}
+
+// RandomAww returns a random aww picture not yet served for key.
+func (f *Feed) RandomAww(key string) (r Reddit, err error) {
+	err = f.next(key, func() (string, MediaSource, error) {
+		var err error
+		r, err = f.Session.RandomAww()
+		return redditID(r), r, err
+	})
+	return
+}
+
+// RandomReddit returns a random reddit post not yet served for key.
+func (f *Feed) RandomReddit(key string, param ParamRandomReddit) (r Reddit, err error) {
+	err = f.next(key, func() (string, MediaSource, error) {
+		var err error
+		r, err = f.Session.RandomReddit(param)
+		return redditID(r), r, err
+	})
+	return
+}
+
+// RandomImage returns a random image not yet served for key.
+func (f *Feed) RandomImage(key string, tag ParamRandomImage) (i Image, err error) {
+	err = f.next(key, func() (string, MediaSource, error) {
+		var err error
+		i, err = f.Session.RandomImage(tag)
+		return i.Snowflake, i, err
+	})
+	return
+}
+
+// Forget clears the history of key.
+func (f *Feed) Forget(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.history, key)
+	if f.Fingerprints != nil {
+		f.Fingerprints.Forget(key)
+	}
+	if f.Store != nil {
+		return f.Store.Save(key, nil)
+	}
+	return nil
+}
+
+func redditID(r Reddit) string {
+	if r.Source != "" {
+		return r.Source
+	}
+	return r.ImageURL
+}
+
+func (f *Feed) next(key string, fetch func() (id string, src MediaSource, err error)) error {
+	attempts := f.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultFeedAttempts
+	}
+
+	for i := 0; i < attempts; i++ {
+		id, src, err := fetch()
+		if err != nil {
+			return err
+		}
+
+		fresh, err := f.remember(key, id)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			continue
+		}
+
+		if f.Fingerprints != nil {
+			dup, _, ferr := f.Fingerprints.Check(context.Background(), key, src)
+			if ferr == nil && dup {
+				continue
+			}
+		}
+		return nil
+	}
+	return ErrNoFreshItem
+}
+
+// remember records id for key and reports whether it had not been seen yet.
+func (f *Feed) remember(key, id string) (fresh bool, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	h, err := f.load(key)
+	if err != nil {
+		return
+	}
+	if _, ok := h.seen[id]; ok {
+		return
+	}
+
+	h.ids = append(h.ids, id)
+	h.seen[id] = struct{}{}
+	size := f.HistorySize
+	if size <= 0 {
+		size = defaultFeedHistory
+	}
+	for len(h.ids) > size {
+		delete(h.seen, h.ids[0])
+		h.ids = h.ids[1:]
+	}
+
+	if f.Store != nil {
+		err = f.Store.Save(key, h.ids)
+	}
+	return true, err
+}
+
+func (f *Feed) load(key string) (*feedHistory, error) {
+	if h, ok := f.history[key]; ok {
+		return h, nil
+	}
+
+	h := &feedHistory{seen: make(map[string]struct{})}
+	if f.Store != nil {
+		ids, err := f.Store.Load(key)
+		if err != nil {
+			return nil, err
+		}
+		h.ids = append(h.ids, ids...)
+		for _, id := range ids {
+			h.seen[id] = struct{}{}
+		}
+	}
+
+	if f.history == nil {
+		f.history = make(map[string]*feedHistory)
+	}
+	f.history[key] = h
+	return h, nil
+}
@@ -0,0 +1,155 @@
+package ksoftgo
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"math/bits"
+	"sync"
+)
+
+const (
+	defaultFingerprintThreshold = 5
+	defaultFingerprintHistory   = 500
+)
+
+// ImageHash is a 64 bit perceptual difference hash (dHash) of an image.
+// Visually similar images have hashes with a small Hamming distance.
+type ImageHash uint64
+
+// Distance returns the number of bits that differ between two hashes.
+func (h ImageHash) Distance(other ImageHash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// DHash computes the difference hash of img: the image is shrunk to 9x8
+// grey cells and each bit records whether a cell is brighter than its right
+// neighbour.
+func DHash(img image.Image) ImageHash {
+	const w, h = 9, 8
+	var sum [h][w]float64
+	var count [h][w]int
+
+	b := img.Bounds()
+	dx, dy := b.Dx(), b.Dy()
+	if dx == 0 || dy == 0 {
+		return 0
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		cy := (y - b.Min.Y) * h / dy
+		for x := b.Min.X; x < b.Max.X; x++ {
+			cx := (x - b.Min.X) * w / dx
+			r, g, bl, _ := img.At(x, y).RGBA()
+			sum[cy][cx] += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)
+			count[cy][cx]++
+		}
+	}
+
+	var grey [h][w]float64
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if count[y][x] > 0 {
+				grey[y][x] = sum[y][x] / float64(count[y][x])
+			} else if x > 0 {
+				// Images narrower than the grid leave cells empty.
+				grey[y][x] = grey[y][x-1]
+			}
+		}
+	}
+
+	var hash ImageHash
+	for y := 0; y < h; y++ {
+		for x := 0; x < w-1; x++ {
+			hash <<= 1
+			if grey[y][x] > grey[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// HashMedia decodes downloaded media and computes its difference hash.
+func HashMedia(m Media) (ImageHash, error) {
+	img, _, err := image.Decode(bytes.NewReader(m.Data))
+	if err != nil {
+		return 0, err
+	}
+	return DHash(img), nil
+}
+
+// Fingerprinter detects visually identical images across different URLs by
+// keeping the perceptual hashes of recently seen media per key.
+type Fingerprinter struct {
+	Session *KSession
+
+	// Threshold is the largest Hamming distance still considered a duplicate.
+	Threshold int
+	// HistorySize is how many hashes are remembered per key.
+	HistorySize int
+	// Options limits the downloads made to hash media.
+	Options DownloadOptions
+
+	mu     sync.Mutex
+	hashes map[string][]ImageHash
+}
+
+// NewFingerprinter creates a fingerprinter with the default threshold and
+// history size.
+func NewFingerprinter(s *KSession) *Fingerprinter {
+	return &Fingerprinter{
+		Session:     s,
+		Threshold:   defaultFingerprintThreshold,
+		HistorySize: defaultFingerprintHistory,
+	}
+}
+
+// Check downloads and hashes the media behind src and reports whether a
+// near-duplicate was already seen for key. New hashes are remembered.
+func (fp *Fingerprinter) Check(ctx context.Context, key string, src MediaSource) (dup bool, hash ImageHash, err error) {
+	m, err := fp.Session.Download(ctx, src, fp.Options)
+	if err != nil {
+		return
+	}
+	hash, err = HashMedia(m)
+	if err != nil {
+		return
+	}
+
+	return fp.Add(key, hash), hash, nil
+}
+
+// Add remembers hash for key and reports whether a near-duplicate of it was
+// already known, in which case it is not added again.
+func (fp *Fingerprinter) Add(key string, hash ImageHash) (dup bool) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	for _, seen := range fp.hashes[key] {
+		if hash.Distance(seen) <= fp.Threshold {
+			return true
+		}
+	}
+
+	if fp.hashes == nil {
+		fp.hashes = make(map[string][]ImageHash)
+	}
+	hashes := append(fp.hashes[key], hash)
+	size := fp.HistorySize
+	if size <= 0 {
+		size = defaultFingerprintHistory
+	}
+	if len(hashes) > size {
+		hashes = hashes[len(hashes)-size:]
+	}
+	fp.hashes[key] = hashes
+	return false
+}
+
+// Forget clears the hashes remembered for key.
+func (fp *Fingerprinter) Forget(key string) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	delete(fp.hashes, key)
+}
@@ -0,0 +1,193 @@
+package ksoftgo
+
+import "encoding/json"
+
+// Weather report types.
+const (
+	ReportCurrently = "currently"
+	ReportMinutely  = "minutely"
+	ReportHourly    = "hourly"
+	ReportDaily     = "daily"
+)
+
+// ForecastInfo is shared by the hourly, daily and minutely reports.
+type ForecastInfo struct {
+	Summary  string          `json:"summary"`
+	Icon     string          `json:"icon"`
+	IconURL  string          `json:"icon_url"`
+	Alerts   []WeatherAlert  `json:"alerts"`
+	Units    string          `json:"units"`
+	Location WeatherLocation `json:"location"`
+}
+
+type MinutelyPoint struct {
+	Time              Timestamp `json:"time"`
+	PrecipIntensity   float64   `json:"precipIntensity"`
+	PrecipProbability float64   `json:"precipProbability"`
+	PrecipType        string    `json:"precipType"`
+}
+
+type HourlyPoint struct {
+	Time                Timestamp `json:"time"`
+	Summary             string    `json:"summary"`
+	Icon                string    `json:"icon"`
+	PrecipIntensity     float64   `json:"precipIntensity"`
+	PrecipProbability   float64   `json:"precipProbability"`
+	PrecipType          string    `json:"precipType"`
+	Temperature         float64   `json:"temperature"`
+	ApparentTemperature float64   `json:"apparentTemperature"`
+	DewPoint            float64   `json:"dewPoint"`
+	Humidity            float64   `json:"humidity"`
+	Pressure            float64   `json:"pressure"`
+	WindSpeed           float64   `json:"windSpeed"`
+	WindGust            float64   `json:"windGust"`
+	WindBearing         int       `json:"windBearing"`
+	CloudCover          float64   `json:"cloudCover"`
+	UvIndex             int       `json:"uvIndex"`
+	Visibility          float64   `json:"visibility"`
+	Ozone               float64   `json:"ozone"`
+}
+
+type DailyPoint struct {
+	Time                    Timestamp `json:"time"`
+	Summary                 string    `json:"summary"`
+	Icon                    string    `json:"icon"`
+	SunriseTime             Timestamp `json:"sunriseTime"`
+	SunsetTime              Timestamp `json:"sunsetTime"`
+	MoonPhase               float64   `json:"moonPhase"`
+	PrecipIntensity         float64   `json:"precipIntensity"`
+	PrecipIntensityMax      float64   `json:"precipIntensityMax"`
+	PrecipProbability       float64   `json:"precipProbability"`
+	PrecipType              string    `json:"precipType"`
+	TemperatureHigh         float64   `json:"temperatureHigh"`
+	TemperatureHighTime     Timestamp `json:"temperatureHighTime"`
+	TemperatureLow          float64   `json:"temperatureLow"`
+	TemperatureLowTime      Timestamp `json:"temperatureLowTime"`
+	TemperatureMin          float64   `json:"temperatureMin"`
+	TemperatureMax          float64   `json:"temperatureMax"`
+	ApparentTemperatureHigh float64   `json:"apparentTemperatureHigh"`
+	ApparentTemperatureLow  float64   `json:"apparentTemperatureLow"`
+	DewPoint                float64   `json:"dewPoint"`
+	Humidity                float64   `json:"humidity"`
+	Pressure                float64   `json:"pressure"`
+	WindSpeed               float64   `json:"windSpeed"`
+	WindGust                float64   `json:"windGust"`
+	WindBearing             int       `json:"windBearing"`
+	CloudCover              float64   `json:"cloudCover"`
+	UvIndex                 int       `json:"uvIndex"`
+	UvIndexTime             Timestamp `json:"uvIndexTime"`
+	Visibility              float64   `json:"visibility"`
+	Ozone                   float64   `json:"ozone"`
+}
+
+type MinutelyData struct {
+	ForecastInfo
+	Data []MinutelyPoint `json:"data"`
+}
+
+type HourlyData struct {
+	ForecastInfo
+	Data []HourlyPoint `json:"data"`
+}
+
+type DailyData struct {
+	ForecastInfo
+	Data []DailyPoint `json:"data"`
+}
+
+type MinutelyWeather struct {
+	Error  bool         `json:"error"`
+	Status int          `json:"status"`
+	Data   MinutelyData `json:"data"`
+}
+
+type HourlyWeather struct {
+	Error  bool       `json:"error"`
+	Status int        `json:"status"`
+	Data   HourlyData `json:"data"`
+}
+
+type DailyWeather struct {
+	Error  bool      `json:"error"`
+	Status int       `json:"status"`
+	Data   DailyData `json:"data"`
+}
+
+// Current weather for a location
+// Example:
+//		weather, err := ksession.CurrentWeather(ksoftgo.ParamWeather{Location: "Montreal"})
+func (s *KSession) CurrentWeather(params ParamWeather) (weather Weather, err error) {
+	params.ReportType = ReportCurrently
+	return s.GetWeather(params)
+}
+
+// Minute by minute forecast for the next hour at a location
+// Example:
+//		forecast, err := ksession.MinutelyForecast(ksoftgo.ParamWeather{Location: "Montreal"})
+func (s *KSession) MinutelyForecast(params ParamWeather) (forecast MinutelyWeather, err error) {
+	params.ReportType = ReportMinutely
+	err = s.forecast(EndpointKumoWeather(params), &forecast)
+	return
+}
+
+// Hour by hour forecast for the next two days at a location
+// Example:
+//		forecast, err := ksession.HourlyForecast(ksoftgo.ParamWeather{Location: "Montreal"})
+func (s *KSession) HourlyForecast(params ParamWeather) (forecast HourlyWeather, err error) {
+	params.ReportType = ReportHourly
+	err = s.forecast(EndpointKumoWeather(params), &forecast)
+	return
+}
+
+// Day by day forecast for the next week at a location
+// Example:
+//		forecast, err := ksession.DailyForecast(ksoftgo.ParamWeather{Location: "Montreal"})
+func (s *KSession) DailyForecast(params ParamWeather) (forecast DailyWeather, err error) {
+	params.ReportType = ReportDaily
+	err = s.forecast(EndpointKumoWeather(params), &forecast)
+	return
+}
+
+// Current weather at coordinates
+// Example:
+//		weather, err := ksession.CurrentWeatherAdv(ksoftgo.ParamAdvWeather{Latitude: 45.5, Longitude: -73.6})
+func (s *KSession) CurrentWeatherAdv(params ParamAdvWeather) (weather Weather, err error) {
+	params.ReportType = ReportCurrently
+	return s.GetAdvWeather(params)
+}
+
+// Minute by minute forecast for the next hour at coordinates
+// Example:
+//		forecast, err := ksession.MinutelyForecastAdv(ksoftgo.ParamAdvWeather{Latitude: 45.5, Longitude: -73.6})
+func (s *KSession) MinutelyForecastAdv(params ParamAdvWeather) (forecast MinutelyWeather, err error) {
+	params.ReportType = ReportMinutely
+	err = s.forecast(EndpointKumoWeatherAdv(params), &forecast)
+	return
+}
+
+// Hour by hour forecast for the next two days at coordinates
+// Example:
+//		forecast, err := ksession.HourlyForecastAdv(ksoftgo.ParamAdvWeather{Latitude: 45.5, Longitude: -73.6})
+func (s *KSession) HourlyForecastAdv(params ParamAdvWeather) (forecast HourlyWeather, err error) {
+	params.ReportType = ReportHourly
+	err = s.forecast(EndpointKumoWeatherAdv(params), &forecast)
+	return
+}
+
+// Day by day forecast for the next week at coordinates
+// Example:
+//		forecast, err := ksession.DailyForecastAdv(ksoftgo.ParamAdvWeather{Latitude: 45.5, Longitude: -73.6})
+func (s *KSession) DailyForecastAdv(params ParamAdvWeather) (forecast DailyWeather, err error) {
+	params.ReportType = ReportDaily
+	err = s.forecast(EndpointKumoWeatherAdv(params), &forecast)
+	return
+}
+
+func (s *KSession) forecast(urlStr string, v interface{}) error {
+	res, err := s.request("GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(res, v)
+}
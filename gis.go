@@ -0,0 +1,134 @@
+package ksoftgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const earthRadiusKm = 6371.0088
+
+// BoundingBox is the rectangle around a GIS result, in degrees. When West is
+// greater than East the box crosses the antimeridian.
+type BoundingBox struct {
+	South float64
+	North float64
+	West  float64
+	East  float64
+}
+
+// UnmarshalJSON decodes the API's [south, north, west, east] array, whose
+// values may be strings or numbers.
+func (b *BoundingBox) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) == 0 {
+		*b = BoundingBox{}
+		return nil
+	}
+	if len(raw) != 4 {
+		return fmt.Errorf("ksoftgo: bounding box has %d values, expected 4", len(raw))
+	}
+
+	var v [4]float64
+	for i, r := range raw {
+		var str string
+		if err := json.Unmarshal(r, &str); err != nil {
+			str = string(r)
+		}
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return fmt.Errorf("ksoftgo: invalid bounding box value %s", r)
+		}
+		v[i] = f
+	}
+	*b = BoundingBox{South: v[0], North: v[1], West: v[2], East: v[3]}
+	return nil
+}
+
+func (b BoundingBox) MarshalJSON() ([]byte, error) {
+	return json.Marshal([4]string{
+		strconv.FormatFloat(b.South, 'f', -1, 64),
+		strconv.FormatFloat(b.North, 'f', -1, 64),
+		strconv.FormatFloat(b.West, 'f', -1, 64),
+		strconv.FormatFloat(b.East, 'f', -1, 64),
+	})
+}
+
+// Contains reports whether a point lies inside the box.
+func (b BoundingBox) Contains(lat, lon float64) bool {
+	if lat < b.South || lat > b.North {
+		return false
+	}
+	if b.West <= b.East {
+		return lon >= b.West && lon <= b.East
+	}
+	return lon >= b.West || lon <= b.East
+}
+
+// Center returns the middle of the box.
+func (b BoundingBox) Center() (lat, lon float64) {
+	lat = (b.South + b.North) / 2
+	east := b.East
+	if b.West > east {
+		east += 360
+	}
+	lon = (b.West + east) / 2
+	if lon > 180 {
+		lon -= 360
+	}
+	return
+}
+
+// Haversine returns the great-circle distance in kilometres between two
+// points given in degrees.
+func Haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// DistanceTo returns the distance in kilometres to another result.
+func (d GISData) DistanceTo(other GISData) float64 {
+	return Haversine(d.Lat, d.Lon, other.Lat, other.Lon)
+}
+
+// MediaURL returns the map image of the result, only set when the lookup
+// was made with IncludeMap.
+func (d GISData) MediaURL() string { return d.Map }
+
+type GISResults struct {
+	Error bool      `json:"error"`
+	Code  int       `json:"code"`
+	Data  []GISData `json:"data"`
+}
+
+// Search for locations and get every match
+// Example:
+//		results, err := ksession.SearchGIS(ksoftgo.ParamGIS{Location: "Springfield"})
+func (s *KSession) SearchGIS(params ParamGIS) (results GISResults, err error) {
+	results = GISResults{}
+	params.More = Bool(true)
+
+	res, err := s.request("GET", EndpointKumoGis(params), nil)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(res, &results)
+	return
+}
+
+// Download the map image of a GIS result looked up with IncludeMap
+// Example:
+//		media, err := ksession.GISMap(ctx, gis.Data, ksoftgo.DownloadOptions{})
+func (s *KSession) GISMap(ctx context.Context, d GISData, opts DownloadOptions) (Media, error) {
+	return s.Download(ctx, d, opts)
+}
@@ -0,0 +1,135 @@
+package ksoftgo
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+)
+
+func TestBoundingBoxUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want BoundingBox
+	}{
+		{`["45.41","45.70","-73.97","-73.47"]`, BoundingBox{45.41, 45.70, -73.97, -73.47}},
+		{`[45.41,45.70,-73.97,-73.47]`, BoundingBox{45.41, 45.70, -73.97, -73.47}},
+		{`[45.41,"45.70",-73.97,"-73.47"]`, BoundingBox{45.41, 45.70, -73.97, -73.47}},
+		{`["-18.3","-16.0","177.1","-179.9"]`, BoundingBox{-18.3, -16.0, 177.1, -179.9}},
+		{`[]`, BoundingBox{}},
+		{`null`, BoundingBox{}},
+	}
+
+	for _, tt := range tests {
+		b := BoundingBox{1, 2, 3, 4}
+		if err := json.Unmarshal([]byte(tt.json), &b); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.json, err)
+			continue
+		}
+		if b != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.json, b, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{`["1","2","3"]`, `["a","2","3","4"]`, `{"south":1}`, `[true,1,2,3]`} {
+		var b BoundingBox
+		if err := json.Unmarshal([]byte(invalid), &b); err == nil {
+			t.Errorf("Unmarshal(%s) = %+v, want error", invalid, b)
+		}
+	}
+}
+
+func TestBoundingBoxRoundTrip(t *testing.T) {
+	b := BoundingBox{-18.3, -16.0, 177.1, -179.9}
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got BoundingBox
+	if err = json.Unmarshal(data, &got); err != nil || got != b {
+		t.Errorf("round trip of %+v = %+v, %v", b, got, err)
+	}
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	montreal := BoundingBox{South: 45.41, North: 45.70, West: -73.97, East: -73.47}
+	// Fiji crosses the antimeridian.
+	fiji := BoundingBox{South: -21, North: -12, West: 177, East: -178}
+
+	tests := []struct {
+		name     string
+		box      BoundingBox
+		lat, lon float64
+		want     bool
+	}{
+		{"inside", montreal, 45.5, -73.6, true},
+		{"on the edge", montreal, 45.41, -73.97, true},
+		{"too far south", montreal, 45.4, -73.6, false},
+		{"too far east", montreal, 45.5, -73.4, false},
+		{"antimeridian west side", fiji, -17, 178, true},
+		{"antimeridian east side", fiji, -17, -179, true},
+		{"antimeridian on 180", fiji, -17, 180, true},
+		{"antimeridian outside", fiji, -17, 0, false},
+		{"antimeridian too far east", fiji, -17, -177, false},
+		{"antimeridian too far north", fiji, -11, 179, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.box.Contains(tt.lat, tt.lon); got != tt.want {
+			t.Errorf("%s: Contains(%v, %v) = %v, want %v", tt.name, tt.lat, tt.lon, got, tt.want)
+		}
+	}
+}
+
+func TestBoundingBoxCenter(t *testing.T) {
+	tests := []struct {
+		box      BoundingBox
+		lat, lon float64
+	}{
+		{BoundingBox{South: 40, North: 50, West: -80, East: -70}, 45, -75},
+		{BoundingBox{South: -20, North: -10, West: 170, East: -170}, -15, 180},
+		{BoundingBox{South: -20, North: -10, West: 175, East: -165}, -15, -175},
+		{BoundingBox{South: -20, North: -10, West: 165, East: -175}, -15, 175},
+	}
+
+	for _, tt := range tests {
+		lat, lon := tt.box.Center()
+		if math.Abs(lat-tt.lat) > 1e-9 || math.Abs(lon-tt.lon) > 1e-9 {
+			t.Errorf("%+v.Center() = %v, %v, want %v, %v", tt.box, lat, lon, tt.lat, tt.lon)
+		}
+		if !tt.box.Contains(lat, lon) {
+			t.Errorf("%+v does not contain its center %v, %v", tt.box, lat, lon)
+		}
+	}
+}
+
+func TestHaversine(t *testing.T) {
+	// Paris to London is about 344 km.
+	if d := Haversine(48.8566, 2.3522, 51.5074, -0.1278); math.Abs(d-343.6) > 1 {
+		t.Errorf("Paris to London = %v km, want about 343.6", d)
+	}
+	// Across the antimeridian the short way round.
+	if d := Haversine(0, 179.5, 0, -179.5); math.Abs(d-111.2) > 0.5 {
+		t.Errorf("across the antimeridian = %v km, want about 111.2", d)
+	}
+	if d := Haversine(10, 20, 10, 20); d != 0 {
+		t.Errorf("same point = %v km, want 0", d)
+	}
+}
+
+func TestGetGISIgnoresMore(t *testing.T) {
+	s := testSession(func(req *http.Request) (*http.Response, error) {
+		if _, ok := req.URL.Query()["more"]; ok {
+			t.Errorf("request %s asks for more results", req.URL)
+		}
+		return jsonResponse(http.StatusOK, `{"data":{"address":"Montreal","lat":45.5,"lon":-73.6}}`), nil
+	})
+
+	gis, err := s.GetGIS(ParamGIS{Location: "Montreal", More: Bool(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gis.Data.Address != "Montreal" {
+		t.Errorf("address = %q, want Montreal", gis.Data.Address)
+	}
+}
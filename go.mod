@@ -1,5 +1,5 @@
 module gopkg.in/KSoft-Si/KSoftgo.v2
 
-go 1.13
+go 1.18
 
-require github.com/google/go-querystring v1.0.0 // indirect
+require github.com/google/go-querystring v1.0.0
@@ -0,0 +1,238 @@
+package ksoftgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidIP   = errors.New("invalid IP address")
+	ErrNonPublicIP = errors.New("IP address is private, loopback or reserved")
+)
+
+const (
+	defaultGeoIPWorkers    = 4
+	defaultGeoIPCacheTTL   = 24 * time.Hour
+	defaultGeoIPCacheLimit = 10000
+)
+
+// reservedPrefixes are special purpose ranges (RFC 6890 and friends) not
+// covered by the netip.Addr predicates. GeoIP has nothing to say about them.
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// CheckPublicIP returns an error unless addr is a public unicast address
+// worth a GeoIP lookup.
+func CheckPublicIP(addr netip.Addr) error {
+	if !addr.IsValid() {
+		return ErrInvalidIP
+	}
+	addr = addr.Unmap()
+	if addr.IsPrivate() || addr.IsLoopback() || addr.IsUnspecified() || addr.IsMulticast() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("%w: %s", ErrNonPublicIP, addr)
+	}
+	for _, p := range reservedPrefixes {
+		if p.Contains(addr) {
+			return fmt.Errorf("%w: %s", ErrNonPublicIP, addr)
+		}
+	}
+	return nil
+}
+
+// GeoIP of an address
+// Example:
+//		geoip, err := ksession.GeoIPAddr(ctx, netip.MustParseAddr("8.8.8.8"))
+func (s *KSession) GeoIPAddr(ctx context.Context, addr netip.Addr) (geoip GeoIP, err error) {
+	if err = CheckPublicIP(addr); err != nil {
+		return
+	}
+	return s.geoIP(ctx, ParamIP{IP: addr.Unmap().String()})
+}
+
+// GeoIPResult is the outcome of one lookup of a bulk GeoIP request.
+type GeoIPResult struct {
+	GeoIP GeoIP
+	Err   error
+}
+
+// GeoIPResolver looks up many addresses with a bounded number of concurrent
+// requests. When CachePrefixes is set results are reused for every address
+// of the same /24 (IPv4) or /48 (IPv6) network for CacheTTL.
+type GeoIPResolver struct {
+	Session *KSession
+
+	// Workers is the number of concurrent requests.
+	Workers int
+	// CachePrefixes enables the per network cache.
+	CachePrefixes bool
+	// CacheTTL is how long a cached network is reused.
+	CacheTTL time.Duration
+	// CacheLimit bounds the number of cached networks.
+	CacheLimit int
+
+	mu    sync.Mutex
+	cache map[netip.Prefix]geoIPEntry
+}
+
+type geoIPEntry struct {
+	geoip   GeoIP
+	expires time.Time
+}
+
+// NewGeoIPResolver creates a resolver running up to workers requests at once.
+func NewGeoIPResolver(s *KSession, workers int, cachePrefixes bool) *GeoIPResolver {
+	if workers <= 0 {
+		workers = defaultGeoIPWorkers
+	}
+	return &GeoIPResolver{
+		Session:       s,
+		Workers:       workers,
+		CachePrefixes: cachePrefixes,
+		CacheTTL:      defaultGeoIPCacheTTL,
+		CacheLimit:    defaultGeoIPCacheLimit,
+	}
+}
+
+// Lookup resolves every address, returning a result or error per address.
+// Private and reserved addresses fail without a request. Addresses not yet
+// started when ctx is cancelled fail with the context's error, and requests
+// in flight are cancelled.
+func (r *GeoIPResolver) Lookup(ctx context.Context, addrs []netip.Addr) map[netip.Addr]GeoIPResult {
+	results := make(map[netip.Addr]GeoIPResult, len(addrs))
+	var mu sync.Mutex
+	set := func(addr netip.Addr, res GeoIPResult) {
+		mu.Lock()
+		results[addr] = res
+		mu.Unlock()
+	}
+
+	workers := r.Workers
+	if workers <= 0 {
+		workers = defaultGeoIPWorkers
+	}
+	queue := make(chan netip.Addr)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr := range queue {
+				geoip, err := r.lookup(ctx, addr)
+				set(addr, GeoIPResult{GeoIP: geoip, Err: err})
+			}
+		}()
+	}
+
+	seen := make(map[netip.Addr]struct{}, len(addrs))
+	for _, addr := range addrs {
+		if _, ok := seen[addr]; ok {
+			continue
+		}
+		seen[addr] = struct{}{}
+
+		if err := CheckPublicIP(addr); err != nil {
+			set(addr, GeoIPResult{Err: err})
+			continue
+		}
+		if ctx.Err() != nil {
+			set(addr, GeoIPResult{Err: ctx.Err()})
+			continue
+		}
+		select {
+		case queue <- addr:
+		case <-ctx.Done():
+			set(addr, GeoIPResult{Err: ctx.Err()})
+		}
+	}
+	close(queue)
+	wg.Wait()
+	return results
+}
+
+func (r *GeoIPResolver) lookup(ctx context.Context, addr netip.Addr) (geoip GeoIP, err error) {
+	if !r.CachePrefixes {
+		return r.Session.GeoIPAddr(ctx, addr)
+	}
+
+	prefix := cachePrefix(addr)
+	now := time.Now()
+	r.mu.Lock()
+	entry, ok := r.cache[prefix]
+	r.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.geoip, nil
+	}
+
+	geoip, err = r.Session.GeoIPAddr(ctx, addr)
+	if err != nil || geoip.Error {
+		return
+	}
+
+	ttl := r.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultGeoIPCacheTTL
+	}
+	r.mu.Lock()
+	if r.cache == nil {
+		r.cache = make(map[netip.Prefix]geoIPEntry)
+	}
+	r.cache[prefix] = geoIPEntry{geoip: geoip, expires: now.Add(ttl)}
+	r.prune(now)
+	r.mu.Unlock()
+	return
+}
+
+// prune drops expired networks once the cache is full, then the networks
+// closest to expiry until it fits again. r.mu must be held.
+func (r *GeoIPResolver) prune(now time.Time) {
+	max := r.CacheLimit
+	if max <= 0 {
+		max = defaultGeoIPCacheLimit
+	}
+	if len(r.cache) <= max {
+		return
+	}
+
+	for prefix, entry := range r.cache {
+		if !now.Before(entry.expires) {
+			delete(r.cache, prefix)
+		}
+	}
+	for len(r.cache) > max {
+		var oldest netip.Prefix
+		var first time.Time
+		for prefix, entry := range r.cache {
+			if first.IsZero() || entry.expires.Before(first) {
+				oldest, first = prefix, entry.expires
+			}
+		}
+		delete(r.cache, oldest)
+	}
+}
+
+func cachePrefix(addr netip.Addr) netip.Prefix {
+	addr = addr.Unmap()
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, _ := addr.Prefix(bits)
+	return prefix
+}
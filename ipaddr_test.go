@@ -0,0 +1,137 @@
+package ksoftgo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckPublicIP(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+		{"::ffff:8.8.8.8", true},
+		{"10.0.0.1", false},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"169.254.1.1", false},
+		{"100.64.0.1", false},
+		{"192.0.2.1", false},
+		{"2001:db8::1", false},
+		{"::ffff:192.168.1.1", false},
+	}
+
+	for _, tt := range tests {
+		err := CheckPublicIP(netip.MustParseAddr(tt.addr))
+		if tt.public && err != nil {
+			t.Errorf("CheckPublicIP(%s) = %v, want nil", tt.addr, err)
+		}
+		if !tt.public && !errors.Is(err, ErrNonPublicIP) {
+			t.Errorf("CheckPublicIP(%s) = %v, want ErrNonPublicIP", tt.addr, err)
+		}
+	}
+	if err := CheckPublicIP(netip.Addr{}); !errors.Is(err, ErrInvalidIP) {
+		t.Errorf("CheckPublicIP(zero) = %v, want ErrInvalidIP", err)
+	}
+}
+
+func addrs(list ...string) []netip.Addr {
+	parsed := make([]netip.Addr, len(list))
+	for i, a := range list {
+		parsed[i] = netip.MustParseAddr(a)
+	}
+	return parsed
+}
+
+func TestGeoIPResolverCache(t *testing.T) {
+	var requests int32
+	r := NewGeoIPResolver(testSession(countingResponder(http.StatusOK, `{"data":{"city":"Mountain View"}}`, &requests)), 2, true)
+
+	results := r.Lookup(context.Background(), addrs("8.8.8.8", "8.8.8.4", "8.8.8.8", "10.0.0.1"))
+	if len(results) != 3 {
+		t.Fatalf("%d results, want 3", len(results))
+	}
+	if res := results[netip.MustParseAddr("8.8.8.4")]; res.Err != nil || res.GeoIP.Data.City != "Mountain View" {
+		t.Errorf("8.8.8.4 = %+v", res)
+	}
+	if res := results[netip.MustParseAddr("10.0.0.1")]; !errors.Is(res.Err, ErrNonPublicIP) {
+		t.Errorf("10.0.0.1 error = %v, want ErrNonPublicIP", res.Err)
+	}
+	if n := atomic.LoadInt32(&requests); n > 2 {
+		t.Errorf("made %d requests for one network, want at most 2", n)
+	}
+
+	// Once cached the network is served without a request until it expires.
+	atomic.StoreInt32(&requests, 0)
+	r.Lookup(context.Background(), addrs("8.8.8.1"))
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Errorf("made %d requests for a cached network, want 0", n)
+	}
+
+	r.mu.Lock()
+	for prefix, entry := range r.cache {
+		entry.expires = time.Now().Add(-time.Second)
+		r.cache[prefix] = entry
+	}
+	r.mu.Unlock()
+	r.Lookup(context.Background(), addrs("8.8.8.1"))
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("made %d requests for an expired network, want 1", n)
+	}
+}
+
+func TestGeoIPResolverSkipsErrorResponses(t *testing.T) {
+	var requests int32
+	r := NewGeoIPResolver(testSession(countingResponder(http.StatusOK, `{"error":true,"code":404}`, &requests)), 1, true)
+
+	r.Lookup(context.Background(), addrs("8.8.8.8"))
+	r.Lookup(context.Background(), addrs("8.8.8.8"))
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("made %d requests, want 2: error responses are not cached", n)
+	}
+}
+
+func TestGeoIPResolverCacheLimit(t *testing.T) {
+	var requests int32
+	r := NewGeoIPResolver(testSession(countingResponder(http.StatusOK, `{"data":{}}`, &requests)), 1, true)
+	r.CacheLimit = 2
+
+	r.Lookup(context.Background(), addrs("1.1.1.1", "8.8.8.8", "9.9.9.9", "2606:4700::1"))
+	if n := len(r.cache); n != 2 {
+		t.Errorf("%d networks cached, want 2", n)
+	}
+}
+
+func TestGeoIPResolverCancelsRequests(t *testing.T) {
+	started := make(chan struct{}, 1)
+	s := testSession(func(req *http.Request) (*http.Response, error) {
+		started <- struct{}{}
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+	r := NewGeoIPResolver(s, 1, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	done := make(chan map[netip.Addr]GeoIPResult)
+	go func() { done <- r.Lookup(ctx, addrs("8.8.8.8")) }()
+	select {
+	case results := <-done:
+		if err := results[netip.MustParseAddr("8.8.8.8")].Err; !errors.Is(err, context.Canceled) {
+			t.Errorf("error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Lookup did not return after cancelling ctx")
+	}
+}
@@ -0,0 +1,66 @@
+package ksoftgo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrGeoIPFailed is returned when the GeoIP response reports an error.
+var ErrGeoIPFailed = errors.New("geoip lookup failed")
+
+// Weather units systems.
+const (
+	UnitsSI = "si"
+	UnitsUS = "us"
+	UnitsCA = "ca"
+	UnitsUK = "uk2"
+)
+
+// UnitsForCountry returns the weather units system used in a country, given
+// its ISO 3166 alpha-2 code: "us" for the countries using imperial units and
+// "si" everywhere else.
+func UnitsForCountry(countryCode string) string {
+	switch strings.ToUpper(countryCode) {
+	case "US", "LR", "MM":
+		return UnitsUS
+	}
+	return UnitsSI
+}
+
+// IPWeather is the location of an IP address and the weather there.
+type IPWeather struct {
+	GeoIP   GeoIP
+	Weather Weather
+}
+
+// Weather at the location of an IP address. Units default to the system of
+// the IP's country when options.Units is empty.
+// Example:
+//		result, err := ksession.WeatherForIP(ksoftgo.ParamIP{IP: "8.8.8.8"}, ksoftgo.OptionalAdvWeather{})
+func (s *KSession) WeatherForIP(param ParamIP, options OptionalAdvWeather) (result IPWeather, err error) {
+	result.GeoIP, err = s.GeoIP(param)
+	if err == nil && result.GeoIP.Error {
+		err = ErrGeoIPFailed
+	}
+	if err != nil {
+		err = fmt.Errorf("weather for %s: geoip: %w", param.IP, err)
+		return
+	}
+
+	d := result.GeoIP.Data
+	if options.Units == "" {
+		options.Units = UnitsForCountry(d.CountryCode)
+	}
+
+	result.Weather, err = s.GetAdvWeather(ParamAdvWeather{
+		Latitude:   d.Latitude,
+		Longitude:  d.Longitude,
+		ReportType: ReportCurrently,
+		Options:    options,
+	})
+	if err != nil {
+		err = fmt.Errorf("weather for %s: weather at %v,%v: %w", param.IP, d.Latitude, d.Longitude, err)
+	}
+	return
+}
@@ -1,10 +1,12 @@
 package ksoftgo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"net/netip"
 	"runtime"
 	"strings"
 	"time"
@@ -33,13 +35,20 @@ func New(token string) (s *KSession, err error) {
 // Example:
 //		image, err := ksession.RandomImage(kosftgo.ParamRandomImage{Tag: "doge"})
 func (s *KSession) RandomImage(tag ParamRandomImage) (i Image, err error) {
-	i = Image{}
-	res, err := s.request("GET", EndpointMemeRandomImage(tag), nil)
-	if err != nil {
+	if err = s.checkSFW(tag.NSFW); err != nil {
 		return
 	}
 
-	err = json.Unmarshal(res, &i)
+	err = s.retryRejected(func() (error, error) {
+		i = Image{}
+		res, err := s.request("GET", EndpointMemeRandomImage(tag), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(res, &i)
+		return s.checkSFWResult(i.NSFW), err
+	})
 	return
 }
 
@@ -47,13 +56,16 @@ func (s *KSession) RandomImage(tag ParamRandomImage) (i Image, err error) {
 // Example:
 //		reddit, err := ksession.RandomMeme()
 func (s *KSession) RandomMeme() (r Reddit, err error) {
-	r = Reddit{}
-	res, err := s.request("GET", EndpointMemeRandomMeme, nil)
-	if err != nil {
-		return
-	}
-
-	err = json.Unmarshal(res, &r)
+	err = s.retryRejected(func() (error, error) {
+		r = Reddit{}
+		res, err := s.request("GET", EndpointMemeRandomMeme, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(res, &r)
+		return s.checkReddit(r), err
+	})
 	return
 }
 
@@ -61,27 +73,37 @@ func (s *KSession) RandomMeme() (r Reddit, err error) {
 // Example:
 //		reddit, err := ksession.RandomAww()
 func (s *KSession) RandomAww() (reddit Reddit, err error) {
-	reddit = Reddit{}
-	res, err := s.request("GET", EndpointMemeRandomAww, nil)
-	if err != nil {
-		return
-	}
-
-	err = json.Unmarshal(res, &reddit)
+	err = s.retryRejected(func() (error, error) {
+		reddit = Reddit{}
+		res, err := s.request("GET", EndpointMemeRandomAww, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(res, &reddit)
+		return s.checkReddit(reddit), err
+	})
 	return
 }
 
 // Get a random reddit post
 // Example:
-//		reddit, err := ksession.RandomReddit(ksoftgo.ParamRandomReddit{SubReddit: "memes", Options: ksoftgo.OptionalRandomReddit{Span: "month"}})
+//		reddit, err := ksession.RandomReddit(ksoftgo.ParamRandomReddit{SubReddit: "memes", Options: ksoftgo.OptionalRandomReddit{Span: ksoftgo.SpanMonth}})
 func (s *KSession) RandomReddit(param ParamRandomReddit) (reddit Reddit, err error) {
-	reddit = Reddit{}
-	res, err := s.request("GET", EndpointMemeRandomReddit(param), nil)
-	if err != nil {
+	if param, err = s.normalizeRandomReddit(param); err != nil {
 		return
 	}
 
-	err = json.Unmarshal(res, &reddit)
+	err = s.retryRejected(func() (error, error) {
+		reddit = Reddit{}
+		res, err := s.request("GET", EndpointMemeRandomReddit(param), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(res, &reddit)
+		return s.checkReddit(reddit), err
+	})
 	return
 }
 
@@ -89,21 +111,19 @@ func (s *KSession) RandomReddit(param ParamRandomReddit) (reddit Reddit, err err
 // Example:
 //		reddit, err := ksession.RandomNSFW()
 func (s *KSession) RandomNSFW() (reddit Reddit, err error) {
-	reddit = Reddit{}
-	res, err := s.request("GET", EndpointMemeRandomNSFW(ParamRandomNSFW{}), nil)
-	if err != nil {
-		return
-	}
-
-	err = json.Unmarshal(res, &reddit)
-	return
+	return s.RandomNSFWOptions(ParamRandomNSFW{})
 }
 
 // Get a random NSFW post with options
 // Example:
-//		reddit, err := ksession.RandomNSFW(ksoftgo.ParamRandomNSFW{GIFsOnly: true})
+//		reddit, err := ksession.RandomNSFWOptions(ksoftgo.ParamRandomNSFW{GIFsOnly: ksoftgo.Bool(true)})
 func (s *KSession) RandomNSFWOptions(options ParamRandomNSFW) (reddit Reddit, err error) {
 	reddit = Reddit{}
+	if s.strict() {
+		err = ErrNSFWBlocked
+		return
+	}
+
 	res, err := s.request("GET", EndpointMemeRandomNSFW(options), nil)
 	if err != nil {
 		return
@@ -117,27 +137,27 @@ func (s *KSession) RandomNSFWOptions(options ParamRandomNSFW) (reddit Reddit, er
 // Example:
 //		image, err := ksession.RandomWikiHow()
 func (s *KSession) RandomWikiHow() (i WikiHowImage, err error) {
-	i = WikiHowImage{}
-	res, err := s.request("GET", EndpointMemeWikihow(ParamWikiHow{}), nil)
-	if err != nil {
-		return
-	}
-
-	err = json.Unmarshal(res, &i)
-	return
+	return s.RandomWikiHowOptions(ParamWikiHow{})
 }
 
 // Get a random WikiHow article with options
 // Example:
-//		image, err := ksession.RandomWikiHow(ksoftgo.ParamWikiHow{NSFW: true})
+//		image, err := ksession.RandomWikiHowOptions(ksoftgo.ParamWikiHow{NSFW: ksoftgo.Bool(true)})
 func (s *KSession) RandomWikiHowOptions(options ParamWikiHow) (i WikiHowImage, err error) {
-	i = WikiHowImage{}
-	res, err := s.request("GET", EndpointMemeWikihow(options), nil)
-	if err != nil {
+	if err = s.checkSFW(options.NSFW); err != nil {
 		return
 	}
 
-	err = json.Unmarshal(res, &i)
+	err = s.retryRejected(func() (error, error) {
+		i = WikiHowImage{}
+		res, err := s.request("GET", EndpointMemeWikihow(options), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(res, &i)
+		return s.checkSFWResult(i.NSFW), err
+	})
 	return
 }
 
@@ -146,12 +166,19 @@ func (s *KSession) RandomWikiHowOptions(options ParamWikiHow) (i WikiHowImage, e
 //		image, err := ksession.ImageBySnowflake("i-ix63ra_m-12")
 func (s *KSession) ImageBySnowflake(snowflake string) (i Image, err error) {
 	i = Image{}
+	if err = checkPathSegment(snowflake); err != nil {
+		return
+	}
 	res, err := s.request("GET", EndpointMemeImage(snowflake), nil)
 	if err != nil {
 		return
 	}
 
 	err = json.Unmarshal(res, &i)
+	if err == nil && i.NSFW && s.strict() {
+		i = Image{}
+		err = ErrNSFWBlocked
+	}
 	return
 }
 
@@ -166,6 +193,9 @@ func (s *KSession) GetTags() (tags Tags, err error) {
 	}
 
 	err = json.Unmarshal(res, &tags)
+	if s.strict() {
+		tags = filterTags(tags)
+	}
 	return
 }
 
@@ -215,7 +245,7 @@ func (s *KSession) CheckBan(param ParamBans) (c bool, err error) {
 
 // Delete ban
 // Example:
-//		ksession.DeleteBan(ksoftgo.ParamDeleteBan{User: "123456789123456789", Force: false})
+//		ksession.DeleteBan(ksoftgo.ParamDeleteBan{User: 123456789123456789, Force: ksoftgo.Bool(false)})
 func (s *KSession) DeleteBan(delete ParamDeleteBan) {
 	_, err := s.request("DELETE", EndpointBansDelete(delete), nil)
 	if err != nil {
@@ -225,7 +255,7 @@ func (s *KSession) DeleteBan(delete ParamDeleteBan) {
 
 // List of bans
 // Example:
-//		banlist, err := ksession.GetBans(ksoftgo.ParamListBans{Page: 1})
+//		banlist, err := ksession.GetBans(ksoftgo.ParamListBans{Page: ksoftgo.Int64(1)})
 func (s *KSession) GetBans(param ParamListBans) (banlist BansList, err error) {
 	banlist = BansList{}
 	res, err := s.request("GET", EndpointBansList(param), nil)
@@ -237,11 +267,13 @@ func (s *KSession) GetBans(param ParamListBans) (banlist BansList, err error) {
 	return
 }
 
-// Search for locations and get maps
+// Search for locations and get maps. Only the best match is returned, More
+// is ignored; use SearchGIS for every match.
 // Example:
 //		gis, err := ksession.GetGis(ksoftgo.ParamGIS{Location: "Montreal"})
 func (s *KSession) GetGIS(params ParamGIS) (gis GIS, err error) {
 	gis = GIS{}
+	params.More = nil
 
 	res, err := s.request("GET", EndpointKumoGis(params), nil)
 	if err != nil {
@@ -257,6 +289,9 @@ func (s *KSession) GetGIS(params ParamGIS) (gis GIS, err error) {
 //		weather, err := ksession.GetWeather(ksoftgo.ParamWeather{Location: "Montreal", ReportType: "currently"})
 func (s *KSession) GetWeather(params ParamWeather) (weather Weather, err error) {
 	weather = Weather{}
+	if err = checkPathSegment(params.ReportType); err != nil {
+		return
+	}
 
 	res, err := s.request("GET", EndpointKumoWeather(params), nil)
 	if err != nil {
@@ -272,6 +307,9 @@ func (s *KSession) GetWeather(params ParamWeather) (weather Weather, err error)
 //		weather, err := ksession.GetAdvWeather(ksoftgo.ParamAdvWeather{Latitude: 0.0, Longitude: 0.0, ReportType: "currently"})
 func (s *KSession) GetAdvWeather(params ParamAdvWeather) (weather Weather, err error) {
 	weather = Weather{}
+	if err = checkPathSegment(params.ReportType); err != nil {
+		return
+	}
 
 	res, err := s.request("GET", EndpointKumoWeatherAdv(params), nil)
 	if err != nil {
@@ -286,8 +324,20 @@ func (s *KSession) GetAdvWeather(params ParamAdvWeather) (weather Weather, err e
 // Example:
 //		geoip, err := ksession.GeoIP(ksoftgo.ParamIP{IP: "8.8.8.8"})
 func (s *KSession) GeoIP(param ParamIP) (geoip GeoIP, err error) {
+	return s.geoIP(context.Background(), param)
+}
+
+func (s *KSession) geoIP(ctx context.Context, param ParamIP) (geoip GeoIP, err error) {
 	geoip = GeoIP{}
-	res, err := s.request("GET", EndpointKumoGeoIP(param), nil)
+	addr, err := netip.ParseAddr(param.IP)
+	if err != nil {
+		err = fmt.Errorf("%w: %q", ErrInvalidIP, param.IP)
+		return
+	}
+	if err = CheckPublicIP(addr); err != nil {
+		return
+	}
+	res, err := s.requestWithContext(ctx, "GET", EndpointKumoGeoIP(param), nil)
 	if err != nil {
 		return
 	}
@@ -301,6 +351,12 @@ func (s *KSession) GeoIP(param ParamIP) (geoip GeoIP, err error) {
 //		currency, err := ksession.CurrenyConversion(ksoftgo.ParamCurrency{FromCurrency: "USD", ToCurrency: "EUR", Value: 1.50})
 func (s *KSession) CurrencyConversion(param ParamCurrency) (curr Currency, err error) {
 	curr = Currency{}
+	if param.CurrencyFrom, err = formatCurrencyCode(string(param.CurrencyFrom)); err != nil {
+		return
+	}
+	if param.CurrencyTo, err = formatCurrencyCode(string(param.CurrencyTo)); err != nil {
+		return
+	}
 	res, err := s.request("GET", EndpointKumoCurrency(param), nil)
 	if err != nil {
 		return
@@ -325,46 +381,25 @@ func (s *KSession) SearchLyrics(param ParamSearchLyrics) (results LyricsSearch,
 	return
 }
 
-// Get artist by ID
+// Get artist by ID, e.g. the ArtistID of a search hit
 // Example:
 //		artist, err := ksession.GetArtist(628942)
-func (s *KSession) GetArtist(id int64) (results Artist, err error) {
-	results = Artist{}
-	res, err := s.request("GET", EndpointLyricsArtist(id), nil)
-	if err != nil {
-		return
-	}
-
-	err = json.Unmarshal(res, &results)
-	return
+func (s *KSession) GetArtist(id ArtistID) (results Artist, err error) {
+	return id.Artist(context.Background(), s)
 }
 
-// Get album by ID
+// Get album by ID, e.g. one of the AlbumIDs of a search hit
 // Example:
 //		album, err := ksession.GetAlbum(88287)
-func (s *KSession) GetAlbum(id int64) (results Album, err error) {
-	results = Album{}
-	res, err := s.request("GET", EndpointLyricsAlbum(id), nil)
-	if err != nil {
-		return
-	}
-
-	err = json.Unmarshal(res, &results)
-	return
+func (s *KSession) GetAlbum(id AlbumID) (results Album, err error) {
+	return id.Album(context.Background(), s)
 }
 
-// Get track by ID
+// Get track by ID, e.g. the TrackID of a search hit
 // Example:
 //		track, err := ksession.GetTrack(680639)
-func (s *KSession) GetTrack(id int64) (results Track, err error) {
-	results = Track{}
-	res, err := s.request("GET", EndpointLyricsTrack(id), nil)
-	if err != nil {
-		return
-	}
-
-	err = json.Unmarshal(res, &results)
-	return
+func (s *KSession) GetTrack(id TrackID) (results Track, err error) {
+	return id.Track(context.Background(), s)
 }
 
 func (s *KSession) log(caller int, format string, a ...interface{}) {
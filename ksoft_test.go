@@ -0,0 +1,37 @@
+package ksoftgo
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// roundTripFunc answers the requests of a test session without a network.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func testSession(rt roundTripFunc) *KSession {
+	return &KSession{Client: &http.Client{Transport: rt}}
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// countingResponder answers every request with status and body and counts
+// the requests.
+func countingResponder(status int, body string, count *int32) roundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(count, 1)
+		return jsonResponse(status, body), nil
+	}
+}
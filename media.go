@@ -0,0 +1,138 @@
+package ksoftgo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrMediaTooLarge = errors.New("media exceeds the maximum size")
+	ErrMediaType     = errors.New("media type not allowed")
+	ErrNoMediaURL    = errors.New("result has no media URL")
+)
+
+var (
+	// DefaultMaxMedia is the largest download accepted when no limit is set.
+	DefaultMaxMedia int64 = 8 << 20
+	// DefaultMediaTypes are the MIME type prefixes accepted when none are set.
+	DefaultMediaTypes = []string{"image/"}
+)
+
+// sniffLen is how much of the body http.DetectContentType looks at.
+const sniffLen = 512
+
+// MediaSource is a result that links to downloadable media.
+type MediaSource interface {
+	MediaURL() string
+}
+
+func (i Image) MediaURL() string        { return i.URL }
+func (r Reddit) MediaURL() string       { return r.ImageURL }
+func (i WikiHowImage) MediaURL() string { return i.URL }
+
+// DownloadOptions limits what Download accepts.
+type DownloadOptions struct {
+	// MaxBytes is the largest body accepted, DefaultMaxMedia when 0.
+	MaxBytes int64
+	// AllowedTypes are the accepted MIME type prefixes, DefaultMediaTypes
+	// when empty.
+	AllowedTypes []string
+}
+
+// Media is downloaded media. Width, Height and Format are only set for
+// formats the standard library can decode (GIF, JPEG and PNG).
+type Media struct {
+	URL         string
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+	Format      string
+}
+
+// Download fetches the media behind a result with the session's HTTP client.
+// The content type is sniffed from the body rather than trusted from the
+// response headers, and the session token is never sent to the media host.
+// Example:
+//		media, err := ksession.Download(ctx, image, ksoftgo.DownloadOptions{MaxBytes: 4 << 20})
+func (s *KSession) Download(ctx context.Context, src MediaSource, opts DownloadOptions) (m Media, err error) {
+	m.URL = src.MediaURL()
+	if m.URL == "" {
+		err = ErrNoMediaURL
+		return
+	}
+
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxMedia
+	}
+	allowed := opts.AllowedTypes
+	if len(allowed) == 0 {
+		allowed = DefaultMediaTypes
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", m.URL, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("User-Agent", s.UserAgent)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("downloading %s: HTTP %s", m.URL, resp.Status)
+		return
+	}
+	if resp.ContentLength > maxBytes {
+		err = ErrMediaTooLarge
+		return
+	}
+
+	m.Data, err = ioutil.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return
+	}
+	if int64(len(m.Data)) > maxBytes {
+		m.Data = nil
+		err = ErrMediaTooLarge
+		return
+	}
+
+	head := m.Data
+	if len(head) > sniffLen {
+		head = head[:sniffLen]
+	}
+	m.ContentType = strings.TrimSpace(strings.Split(http.DetectContentType(head), ";")[0])
+	if !allowedType(m.ContentType, allowed) {
+		err = fmt.Errorf("%w: %s", ErrMediaType, m.ContentType)
+		return
+	}
+
+	if cfg, format, cerr := image.DecodeConfig(bytes.NewReader(m.Data)); cerr == nil {
+		m.Width, m.Height, m.Format = cfg.Width, cfg.Height, format
+	}
+	return
+}
+
+func allowedType(contentType string, allowed []string) bool {
+	for _, prefix := range allowed {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
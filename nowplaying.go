@@ -0,0 +1,202 @@
+package ksoftgo
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ErrNoLyrics is returned when a lyrics search has no hits.
+var ErrNoLyrics = errors.New("no lyrics found")
+
+const bestMatchCandidates = 10
+
+// NowPlaying is a track title split into its parts.
+type NowPlaying struct {
+	Artist    string
+	Title     string
+	Featuring []string
+}
+
+var (
+	// decorationWord matches the words of a bracketed part that is only
+	// decoration, e.g. "(Official Video)" or "[HD]".
+	decorationWord = regexp.MustCompile(`(?i)^(?:official|music|video|audio|lyrics?|hd|hq|4k|remaster(?:ed)?|visuali[sz]er|explicit|clean|m/v|mv|version|\d{4})$`)
+	bracketed      = regexp.MustCompile(`\s*[(\[【]([^)\]】]*)[)\]】]`)
+	// featuring matches a credit after the artist or title, so an artist
+	// whose name starts with "FT" is not taken for one.
+	featuring       = regexp.MustCompile(`(?i)\s+(?:feat\.?|ft\.?|featuring)\s+(.+)$`)
+	featuringInside = regexp.MustCompile(`(?i)^\s*(?:feat\.?|ft\.?|featuring)\s+(.+)$`)
+	featSeparators  = regexp.MustCompile(`\s*[,&]\s*`)
+	trailingNoise   = regexp.MustCompile(`(?i)\s*(?:\|.*|\bofficial (?:music )?video\b.*|\blyrics?\b\s*)$`)
+	artistSeparator = regexp.MustCompile(`\s+[-–—~]\s+`)
+)
+
+// NormalizeTitle splits a now-playing title such as
+// "Artist - Song (Official Video) [HD] ft. X" into artist, title and
+// featured artists, dropping the decorations. The artist is empty when the
+// title has no "Artist - " part.
+func NormalizeTitle(raw string) (np NowPlaying) {
+	s := strings.TrimSpace(raw)
+
+	s = bracketed.ReplaceAllStringFunc(s, func(m string) string {
+		inner := bracketed.FindStringSubmatch(m)[1]
+		if f := featuringInside.FindStringSubmatch(inner); f != nil {
+			np.Featuring = append(np.Featuring, splitFeaturing(f[1])...)
+			return ""
+		}
+		if isDecoration(inner) {
+			return ""
+		}
+		return m
+	})
+	s = trailingNoise.ReplaceAllString(s, "")
+
+	if parts := artistSeparator.Split(s, 2); len(parts) == 2 {
+		np.Artist, s = parts[0], parts[1]
+	}
+	if f := featuring.FindStringSubmatch(np.Artist); f != nil {
+		np.Featuring = append(np.Featuring, splitFeaturing(f[1])...)
+		np.Artist = np.Artist[:len(np.Artist)-len(f[0])]
+	}
+	if f := featuring.FindStringSubmatch(s); f != nil {
+		np.Featuring = append(np.Featuring, splitFeaturing(f[1])...)
+		s = s[:len(s)-len(f[0])]
+	}
+
+	np.Artist = strings.TrimSpace(np.Artist)
+	np.Title = strings.Trim(strings.TrimSpace(s), `"'`)
+	return
+}
+
+// isDecoration reports whether every word of a bracketed part is decoration,
+// so "(Clean)" is dropped but "(Clean Bandit Remix)" is kept.
+func isDecoration(inner string) bool {
+	words := strings.FieldsFunc(inner, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == '-' || r == '|'
+	})
+	decorated := false
+	for _, w := range words {
+		if !decorationWord.MatchString(w) {
+			return false
+		}
+		decorated = decorated || !unicode.IsDigit([]rune(w)[0])
+	}
+	return decorated
+}
+
+func splitFeaturing(s string) (names []string) {
+	for _, name := range featSeparators.Split(s, -1) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return
+}
+
+// Query returns the search query for the track.
+func (np NowPlaying) Query() string {
+	return strings.TrimSpace(np.Artist + " " + np.Title)
+}
+
+// LyricsMatch is the search hit best matching a now-playing title.
+type LyricsMatch struct {
+	Hit LyricsHit
+	// Confidence is between 0 and 1, how closely the hit's artist and
+	// title match the normalized ones.
+	Confidence float64
+	Query      NowPlaying
+}
+
+// Get the lyrics best matching a now-playing title
+// Example:
+//		match, err := ksession.BestLyricsMatch("Rick Astley - Never Gonna Give You Up (Official Music Video)")
+func (s *KSession) BestLyricsMatch(raw string) (match LyricsMatch, err error) {
+	match.Query = NormalizeTitle(raw)
+	query := match.Query.Query()
+	if query == "" {
+		query = strings.TrimSpace(raw)
+	}
+
+	results, err := s.SearchLyrics(ParamSearchLyrics{Query: query, Limit: Int(bestMatchCandidates)})
+	if err != nil {
+		return
+	}
+	if len(results.Data) == 0 {
+		err = ErrNoLyrics
+		return
+	}
+
+	maxScore := 0.0
+	for _, hit := range results.Data {
+		if hit.SearchScore > maxScore {
+			maxScore = hit.SearchScore
+		}
+	}
+
+	match.Confidence = -1
+	for _, hit := range results.Data {
+		c := scoreLyricsHit(match.Query, hit, maxScore)
+		if c > match.Confidence {
+			match.Hit, match.Confidence = hit, c
+		}
+	}
+	return
+}
+
+// scoreLyricsHit weighs the title and artist similarity of a hit with its
+// search score relative to the best hit.
+func scoreLyricsHit(np NowPlaying, hit LyricsHit, maxScore float64) float64 {
+	search := 0.0
+	if maxScore > 0 {
+		search = hit.SearchScore / maxScore
+	}
+
+	title := similarity(np.Title, hit.Name)
+	if np.Artist == "" {
+		return 0.85*title + 0.15*search
+	}
+	return 0.5*title + 0.4*similarity(np.Artist, hit.Artist) + 0.1*search
+}
+
+// similarity compares two names ignoring case and punctuation, 1 being equal.
+func similarity(a, b string) float64 {
+	a, b = simplify(a), simplify(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	longest := len([]rune(a))
+	if n := len([]rune(b)); n > longest {
+		longest = n
+	}
+	sim := 1 - float64(levenshtein(a, b))/float64(longest)
+	// A name contained in the other, like "Song" in "Song - Remastered",
+	// is a strong match even when the lengths differ.
+	if strings.Contains(a, b) || strings.Contains(b, a) {
+		if sim < 0.8 {
+			sim = 0.8
+		}
+	}
+	return sim
+}
+
+func simplify(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			space = false
+		case !space && b.Len() > 0:
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
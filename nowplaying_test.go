@@ -0,0 +1,100 @@
+package ksoftgo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want NowPlaying
+	}{
+		{
+			"Artist - Song (Official Video) [HD] ft. X",
+			NowPlaying{Artist: "Artist", Title: "Song", Featuring: []string{"X"}},
+		},
+		{
+			"Artist - Song (feat. A & B)",
+			NowPlaying{Artist: "Artist", Title: "Song", Featuring: []string{"A", "B"}},
+		},
+		{
+			"Artist - Song ft. A, B & C",
+			NowPlaying{Artist: "Artist", Title: "Song", Featuring: []string{"A", "B", "C"}},
+		},
+		{
+			"Artist – Song",
+			NowPlaying{Artist: "Artist", Title: "Song"},
+		},
+		{
+			"Artist — Song [Official Audio]",
+			NowPlaying{Artist: "Artist", Title: "Song"},
+		},
+		{
+			"Artist feat. Guest - Song",
+			NowPlaying{Artist: "Artist", Title: "Song", Featuring: []string{"Guest"}},
+		},
+		{
+			"Artist - Song | Lyrics",
+			NowPlaying{Artist: "Artist", Title: "Song"},
+		},
+		{
+			"Artist - Song Lyrics",
+			NowPlaying{Artist: "Artist", Title: "Song"},
+		},
+		{
+			"Artist - Song (Live)",
+			NowPlaying{Artist: "Artist", Title: "Song (Live)"},
+		},
+		{
+			"Song (Official Video)",
+			NowPlaying{Title: "Song"},
+		},
+		{
+			`"Song"`,
+			NowPlaying{Title: "Song"},
+		},
+		{
+			"Artist - Song feat. Simon and Garfunkel",
+			NowPlaying{Artist: "Artist", Title: "Song", Featuring: []string{"Simon and Garfunkel"}},
+		},
+		{
+			"Well-Known Artist - Song",
+			NowPlaying{Artist: "Well-Known Artist", Title: "Song"},
+		},
+		{
+			"FT Island - Love Love Love",
+			NowPlaying{Artist: "FT Island", Title: "Love Love Love"},
+		},
+		{
+			"FT Island - Love Love Love ft. Guest",
+			NowPlaying{Artist: "FT Island", Title: "Love Love Love", Featuring: []string{"Guest"}},
+		},
+		{
+			"Song (Clean Bandit Remix)",
+			NowPlaying{Title: "Song (Clean Bandit Remix)"},
+		},
+		{
+			"Artist - Song (Clean)",
+			NowPlaying{Artist: "Artist", Title: "Song"},
+		},
+		{
+			"Artist - Song (Official Music Video) [Remastered 2009]",
+			NowPlaying{Artist: "Artist", Title: "Song"},
+		},
+		{
+			"Prince - 1999 (1999)",
+			NowPlaying{Artist: "Prince", Title: "1999 (1999)"},
+		},
+		{
+			"",
+			NowPlaying{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTitle(tt.raw); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("NormalizeTitle(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package ksoftgo
+
+import "errors"
+
+// ContentPolicy controls whether a session may serve NSFW content.
+type ContentPolicy int
+
+const (
+	// ContentPolicyDefault passes requests and results through unchanged.
+	ContentPolicyDefault ContentPolicy = iota
+	// ContentPolicyStrict refuses NSFW endpoints and parameters, hides NSFW
+	// tags, asks the API to remove NSFW posts and re-checks the NSFW flag of
+	// every returned post or image.
+	ContentPolicyStrict
+)
+
+// ErrNSFWBlocked is returned when a request or its result is refused by a
+// strict content policy.
+var ErrNSFWBlocked = errors.New("NSFW content blocked by content policy")
+
+// filterRetries is how many times a random endpoint is asked again when its
+// result is refused by the session's content policy or subreddit lists.
+const filterRetries = 3
+
+func (s *KSession) strict() bool {
+	return s.ContentPolicy == ContentPolicyStrict
+}
+
+// checkSFW refuses a request that explicitly asks for NSFW content.
+func (s *KSession) checkSFW(nsfw *bool) error {
+	if s.strict() && nsfw != nil && *nsfw {
+		return ErrNSFWBlocked
+	}
+	return nil
+}
+
+// checkSFWResult refuses a result flagged as NSFW under a strict policy.
+func (s *KSession) checkSFWResult(nsfw bool) error {
+	if s.strict() && nsfw {
+		return ErrNSFWBlocked
+	}
+	return nil
+}
+
+// retryRejected calls fetch until it returns a result that is not rejected,
+// giving up with the rejection after filterRetries retries.
+func (s *KSession) retryRejected(fetch func() (rejected, err error)) error {
+	for i := 0; ; i++ {
+		rejected, err := fetch()
+		if err != nil || rejected == nil {
+			return err
+		}
+		if i >= filterRetries {
+			return rejected
+		}
+	}
+}
+
+// filterTags removes NSFW tags and models from tags.
+func filterTags(tags Tags) Tags {
+	models := tags.Models[:0]
+	for _, m := range tags.Models {
+		if !m.Nsfw {
+			models = append(models, m)
+		}
+	}
+	tags.Models = models
+	tags.NsfwTags = nil
+	return tags
+}
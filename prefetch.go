@@ -0,0 +1,279 @@
+package ksoftgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrPrefetcherClosed is returned by a Prefetcher after Close was called.
+var ErrPrefetcherClosed = errors.New("prefetcher closed")
+
+const (
+	defaultPrefetchSize     = 5
+	defaultPrefetchInterval = time.Second
+	prefetchErrorBackoff    = 5 * time.Second
+	prefetchIdleTimeout     = 10 * time.Minute
+)
+
+// Prefetcher keeps a bounded buffer of ready results for each random endpoint
+// and parameter set it is asked for, refilling them in the background so
+// callers rarely wait on a round trip. A buffer is created on first use;
+// while it is empty results are fetched directly. Buffers that have not been
+// read for a while, or whose refill failed with an error retrying cannot fix,
+// are dropped along with their background refill.
+type Prefetcher struct {
+	Session *KSession
+
+	size     int
+	idle     time.Duration
+	ticker   *time.Ticker
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	buffers  map[string]*prefetchBuffer
+	isClosed bool
+}
+
+type prefetchBuffer struct {
+	key      string
+	items    chan interface{}
+	fetch    func() (interface{}, error)
+	lastRead time.Time
+}
+
+// NewPrefetcher creates a prefetcher keeping up to size results per buffer.
+// Background requests of all buffers together are spaced by at least
+// interval, so refills stay within the API's rate limits.
+func NewPrefetcher(s *KSession, size int, interval time.Duration) *Prefetcher {
+	if size <= 0 {
+		size = defaultPrefetchSize
+	}
+	if interval <= 0 {
+		interval = defaultPrefetchInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Prefetcher{
+		Session: s,
+		size:    size,
+		idle:    prefetchIdleTimeout,
+		ticker:  time.NewTicker(interval),
+		ctx:     ctx,
+		cancel:  cancel,
+		buffers: make(map[string]*prefetchBuffer),
+	}
+}
+
+// RandomMeme returns a prefetched random meme.
+func (p *Prefetcher) RandomMeme() (r Reddit, err error) {
+	v, err := p.get(EndpointMemeRandomMeme, func() (interface{}, error) {
+		return p.Session.RandomMeme()
+	})
+	if err == nil {
+		r = v.(Reddit)
+	}
+	return
+}
+
+// RandomAww returns a prefetched random aww picture.
+func (p *Prefetcher) RandomAww() (r Reddit, err error) {
+	v, err := p.get(EndpointMemeRandomAww, func() (interface{}, error) {
+		return p.Session.RandomAww()
+	})
+	if err == nil {
+		r = v.(Reddit)
+	}
+	return
+}
+
+// RandomReddit returns a prefetched random post of a subreddit.
+func (p *Prefetcher) RandomReddit(param ParamRandomReddit) (r Reddit, err error) {
+	if param, err = p.Session.normalizeRandomReddit(param); err != nil {
+		return
+	}
+
+	v, err := p.get(EndpointMemeRandomReddit(param), func() (interface{}, error) {
+		return p.Session.RandomReddit(param)
+	})
+	if err == nil {
+		r = v.(Reddit)
+	}
+	return
+}
+
+// RandomImage returns a prefetched random image for a tag.
+func (p *Prefetcher) RandomImage(tag ParamRandomImage) (i Image, err error) {
+	v, err := p.get(EndpointMemeRandomImage(tag), func() (interface{}, error) {
+		return p.Session.RandomImage(tag)
+	})
+	if err == nil {
+		i = v.(Image)
+	}
+	return
+}
+
+// RandomWikiHow returns a prefetched random WikiHow article.
+func (p *Prefetcher) RandomWikiHow(options ParamWikiHow) (i WikiHowImage, err error) {
+	v, err := p.get(EndpointMemeWikihow(options), func() (interface{}, error) {
+		return p.Session.RandomWikiHowOptions(options)
+	})
+	if err == nil {
+		i = v.(WikiHowImage)
+	}
+	return
+}
+
+// Depth reports how many ready results each buffer holds, keyed by the
+// endpoint URL the buffer fetches.
+func (p *Prefetcher) Depth() map[string]int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	depth := make(map[string]int, len(p.buffers))
+	for key, b := range p.buffers {
+		depth[key] = len(b.items)
+	}
+	return depth
+}
+
+// Close stops all background refills, waits for them to finish and drops
+// the buffered results.
+func (p *Prefetcher) Close() {
+	p.mu.Lock()
+	if p.isClosed {
+		p.mu.Unlock()
+		return
+	}
+	p.isClosed = true
+	p.mu.Unlock()
+
+	p.cancel()
+	p.wg.Wait()
+	p.ticker.Stop()
+
+	p.mu.Lock()
+	for key, b := range p.buffers {
+		for len(b.items) > 0 {
+			<-b.items
+		}
+		delete(p.buffers, key)
+	}
+	p.mu.Unlock()
+}
+
+func (p *Prefetcher) get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	p.mu.Lock()
+	if p.isClosed {
+		p.mu.Unlock()
+		return nil, ErrPrefetcherClosed
+	}
+	b, ok := p.buffers[key]
+	if !ok {
+		b = &prefetchBuffer{key: key, items: make(chan interface{}, p.size), fetch: fetch}
+		p.buffers[key] = b
+		p.wg.Add(1)
+		go p.refill(b)
+	}
+	b.lastRead = time.Now()
+	p.mu.Unlock()
+
+	select {
+	case v := <-b.items:
+		return v, nil
+	default:
+		return fetch()
+	}
+}
+
+func (p *Prefetcher) refill(b *prefetchBuffer) {
+	defer p.wg.Done()
+
+	// A full buffer blocks on the send below, so idleness is also checked
+	// while waiting there.
+	idleCheck := time.NewTicker(p.idle)
+	defer idleCheck.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-p.ticker.C:
+		}
+
+		if p.isIdle(b) {
+			p.drop(b)
+			return
+		}
+
+		v, err := b.fetch()
+		if err != nil {
+			if !prefetchRetryable(err) {
+				p.drop(b)
+				return
+			}
+			backoff := prefetchErrorBackoff
+			if errors.Is(err, ErrRatelimited) && p.Session.RetryAfter > 0 {
+				backoff = p.Session.RetryAfter
+			}
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			continue
+		}
+
+	send:
+		for {
+			select {
+			case <-p.ctx.Done():
+				return
+			case b.items <- v:
+				break send
+			case <-idleCheck.C:
+				if p.isIdle(b) {
+					p.drop(b)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *Prefetcher) isIdle(b *prefetchBuffer) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return time.Since(b.lastRead) > p.idle
+}
+
+// drop removes b from the buffers and discards its results. A later get for
+// the same key starts a fresh buffer.
+func (p *Prefetcher) drop(b *prefetchBuffer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.buffers[b.key] == b {
+		delete(p.buffers, b.key)
+	}
+	for len(b.items) > 0 {
+		<-b.items
+	}
+}
+
+// prefetchRetryable reports whether a failed refill may succeed later. Client
+// errors and rejected content will fail the same way on every attempt.
+func prefetchRetryable(err error) bool {
+	if errors.Is(err, ErrNSFWBlocked) || errors.Is(err, ErrInvalidPathSegment) {
+		return false
+	}
+
+	var restErr *RESTError
+	if errors.As(err, &restErr) && restErr.Response != nil {
+		code := restErr.Response.StatusCode
+		return code < 400 || code >= 500
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package ksoftgo
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitRefills waits until every refill goroutine of p has returned.
+func waitRefills(t *testing.T, p *Prefetcher) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("refill goroutine still running")
+	}
+}
+
+func TestPrefetcherDropsIdleBuffer(t *testing.T) {
+	p := NewPrefetcher(&KSession{}, 1, time.Millisecond)
+	defer p.Close()
+	p.idle = 50 * time.Millisecond
+
+	var fetches int32
+	fetch := func() (interface{}, error) {
+		return atomic.AddInt32(&fetches, 1), nil
+	}
+	if _, err := p.get("key", fetch); err != nil {
+		t.Fatal(err)
+	}
+
+	waitRefills(t, p)
+	if depth := p.Depth(); len(depth) != 0 {
+		t.Errorf("Depth() = %v, want no buffers", depth)
+	}
+}
+
+func TestPrefetcherDropsBufferOnPermanentError(t *testing.T) {
+	p := NewPrefetcher(&KSession{}, 1, time.Millisecond)
+	defer p.Close()
+
+	var fetches int32
+	fetch := func() (interface{}, error) {
+		atomic.AddInt32(&fetches, 1)
+		return nil, ErrNSFWBlocked
+	}
+	if _, err := p.get("key", fetch); err != ErrNSFWBlocked {
+		t.Fatalf("get() error = %v, want ErrNSFWBlocked", err)
+	}
+
+	waitRefills(t, p)
+	if depth := p.Depth(); len(depth) != 0 {
+		t.Errorf("Depth() = %v, want no buffers", depth)
+	}
+	if n := atomic.LoadInt32(&fetches); n != 2 {
+		t.Errorf("fetched %d times, want 2", n)
+	}
+}
+
+func TestPrefetcherServesBufferedItems(t *testing.T) {
+	p := NewPrefetcher(&KSession{}, 2, time.Millisecond)
+	defer p.Close()
+
+	var fetches int32
+	fetch := func() (interface{}, error) {
+		return atomic.AddInt32(&fetches, 1), nil
+	}
+	if _, err := p.get("key", fetch); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for p.Depth()["key"] < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("buffer not filled, Depth() = %v", p.Depth())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := p.get("key", func() (interface{}, error) {
+		t.Error("fetched directly while the buffer was full")
+		return nil, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,115 @@
+package ksoftgo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidSubreddit    = errors.New("invalid subreddit name")
+	ErrSubredditNotAllowed = errors.New("subreddit not allowed")
+	ErrInvalidSpan         = errors.New("invalid span")
+)
+
+// Span is the time span random reddit posts are picked from.
+type Span string
+
+const (
+	SpanHour  Span = "hour"
+	SpanDay   Span = "day"
+	SpanWeek  Span = "week"
+	SpanMonth Span = "month"
+	SpanYear  Span = "year"
+	SpanAll   Span = "all"
+)
+
+// Valid reports whether sp is empty, meaning the server default, or one of
+// the known spans.
+func (sp Span) Valid() bool {
+	switch sp {
+	case "", SpanHour, SpanDay, SpanWeek, SpanMonth, SpanYear, SpanAll:
+		return true
+	}
+	return false
+}
+
+// NormalizeSubreddit strips "r/", "/r/" and trailing slashes from a
+// subreddit name and lower-cases it. Names must be 2 to 21 letters, digits
+// or underscores and may not start with an underscore.
+// Example:
+//		name, err := ksoftgo.NormalizeSubreddit("/r/Memes/")
+func NormalizeSubreddit(name string) (string, error) {
+	n := strings.TrimSpace(name)
+	n = strings.TrimPrefix(n, "/")
+	if strings.HasPrefix(strings.ToLower(n), "r/") {
+		n = n[2:]
+	}
+	n = strings.ToLower(strings.TrimSuffix(n, "/"))
+
+	if len(n) < 2 || len(n) > 21 || n[0] == '_' {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSubreddit, name)
+	}
+	for _, c := range n {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_') {
+			return "", fmt.Errorf("%w: %q", ErrInvalidSubreddit, name)
+		}
+	}
+	return n, nil
+}
+
+// checkSubreddit applies the session's subreddit allow and deny lists to a
+// normalized subreddit name.
+func (s *KSession) checkSubreddit(name string) error {
+	for _, denied := range s.DeniedSubreddits {
+		if d, err := NormalizeSubreddit(denied); err == nil && d == name {
+			return fmt.Errorf("%w: %s", ErrSubredditNotAllowed, name)
+		}
+	}
+	if len(s.AllowedSubreddits) == 0 {
+		return nil
+	}
+	for _, allowed := range s.AllowedSubreddits {
+		if a, err := NormalizeSubreddit(allowed); err == nil && a == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrSubredditNotAllowed, name)
+}
+
+// checkReddit refuses a returned post that is NSFW under a strict content
+// policy or comes from a subreddit the session does not allow. Subreddit
+// names are only checked when AllowedSubreddits or DeniedSubreddits is set.
+func (s *KSession) checkReddit(r Reddit) error {
+	if err := s.checkSFWResult(r.NSFW); err != nil {
+		return err
+	}
+	if r.Subreddit == "" || (len(s.AllowedSubreddits) == 0 && len(s.DeniedSubreddits) == 0) {
+		return nil
+	}
+	name, err := NormalizeSubreddit(r.Subreddit)
+	if err != nil {
+		return err
+	}
+	return s.checkSubreddit(name)
+}
+
+// normalizeRandomReddit validates param and normalizes its subreddit name.
+func (s *KSession) normalizeRandomReddit(param ParamRandomReddit) (ParamRandomReddit, error) {
+	name, err := NormalizeSubreddit(param.SubReddit)
+	if err != nil {
+		return param, err
+	}
+	if err = s.checkSubreddit(name); err != nil {
+		return param, err
+	}
+	if !param.Options.Span.Valid() {
+		return param, fmt.Errorf("%w: %q", ErrInvalidSpan, param.Options.Span)
+	}
+
+	param.SubReddit = name
+	if s.strict() {
+		param.Options.RemoveNSFW = Bool(true)
+	}
+	return param, nil
+}
@@ -0,0 +1,173 @@
+package ksoftgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Typed IDs of the lyrics API. They decode from JSON numbers as well as
+// numeric strings, as the API uses both.
+type (
+	ArtistID int64
+	AlbumID  int64
+	TrackID  int64
+)
+
+// AlbumIDs decodes the comma separated album ID list of search hits.
+type AlbumIDs []AlbumID
+
+func (id *ArtistID) UnmarshalJSON(b []byte) error { return unmarshalID(b, (*int64)(id)) }
+func (id *AlbumID) UnmarshalJSON(b []byte) error  { return unmarshalID(b, (*int64)(id)) }
+func (id *TrackID) UnmarshalJSON(b []byte) error  { return unmarshalID(b, (*int64)(id)) }
+
+func (ids *AlbumIDs) UnmarshalJSON(b []byte) error {
+	var list []AlbumID
+	if err := json.Unmarshal(b, &list); err == nil {
+		*ids = list
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return fmt.Errorf("ksoftgo: invalid album IDs %s", b)
+	}
+	*ids = nil
+	for _, part := range strings.Split(str, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return fmt.Errorf("ksoftgo: invalid album ID %q", part)
+		}
+		*ids = append(*ids, AlbumID(id))
+	}
+	return nil
+}
+
+func unmarshalID(b []byte, id *int64) error {
+	b = bytes.Trim(bytes.TrimSpace(b), `"`)
+	if len(b) == 0 || string(b) == "null" {
+		*id = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return fmt.Errorf("ksoftgo: invalid ID %s", b)
+	}
+	*id = v
+	return nil
+}
+
+// UnmarshalJSON decodes a search hit, reading the first year of the comma
+// separated album_year list into AlbumYear.
+func (h *LyricsHit) UnmarshalJSON(b []byte) error {
+	type hit LyricsHit
+	aux := struct {
+		*hit
+		AlbumYear json.RawMessage `json:"album_year"`
+	}{hit: (*hit)(h)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	h.AlbumYear = 0
+	year := strings.Trim(string(bytes.TrimSpace(aux.AlbumYear)), `"`)
+	if i := strings.Index(year, ","); i >= 0 {
+		year = year[:i]
+	}
+	if year = strings.TrimSpace(year); year != "" && year != "null" {
+		y, err := strconv.Atoi(year)
+		if err != nil {
+			return fmt.Errorf("ksoftgo: invalid album year %s", aux.AlbumYear)
+		}
+		h.AlbumYear = y
+	}
+	return nil
+}
+
+// Navigation helpers that fetch the full record behind an ID or a reference
+// embedded in another response.
+
+// Artist fetches the full artist record.
+func (id ArtistID) Artist(ctx context.Context, s *KSession) (artist Artist, err error) {
+	res, err := s.requestWithContext(ctx, "GET", EndpointLyricsArtist(int64(id)), nil)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(res, &artist)
+	return
+}
+
+// Album fetches the full album record.
+func (id AlbumID) Album(ctx context.Context, s *KSession) (album Album, err error) {
+	res, err := s.requestWithContext(ctx, "GET", EndpointLyricsAlbum(int64(id)), nil)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(res, &album)
+	return
+}
+
+// Track fetches the full track record, including the lyrics.
+func (id TrackID) Track(ctx context.Context, s *KSession) (track Track, err error) {
+	res, err := s.requestWithContext(ctx, "GET", EndpointLyricsTrack(int64(id)), nil)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(res, &track)
+	return
+}
+
+// Artist fetches the full artist record.
+func (a ArtistRef) Artist(ctx context.Context, s *KSession) (Artist, error) {
+	return a.ID.Artist(ctx, s)
+}
+
+// Album fetches the full album record.
+func (a AlbumRef) Album(ctx context.Context, s *KSession) (Album, error) {
+	return a.ID.Album(ctx, s)
+}
+
+// Track fetches the full track record, including the lyrics.
+func (t TrackRef) Track(ctx context.Context, s *KSession) (Track, error) {
+	return t.ID.Track(ctx, s)
+}
+
+// Track fetches the full track record of a search hit.
+func (h LyricsHit) Track(ctx context.Context, s *KSession) (Track, error) {
+	return h.ID.Track(ctx, s)
+}
+
+// ArtistRecord fetches the full artist record of a search hit.
+func (h LyricsHit) ArtistRecord(ctx context.Context, s *KSession) (Artist, error) {
+	return h.ArtistID.Artist(ctx, s)
+}
+
+// AlbumRecord fetches the first album of a search hit.
+func (h LyricsHit) AlbumRecord(ctx context.Context, s *KSession) (album Album, err error) {
+	if len(h.AlbumIDs) == 0 {
+		err = fmt.Errorf("ksoftgo: search hit %d has no album", h.ID)
+		return
+	}
+	return h.AlbumIDs[0].Album(ctx, s)
+}
+
+// Info fetches the full ban information of a ban list entry.
+func (b BanEntry) Info(ctx context.Context, s *KSession) (info BanInfo, err error) {
+	res, err := s.requestWithContext(ctx, "GET", EndpointBansInfo(ParamBans{UserID: b.ID}), nil)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(res, &info)
+	return
+}
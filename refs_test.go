@@ -0,0 +1,56 @@
+package ksoftgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLyricsHitIDs(t *testing.T) {
+	tests := []struct {
+		json   string
+		track  TrackID
+		artist ArtistID
+		albums AlbumIDs
+	}{
+		{`{"id":680639,"artist_id":628942,"album_ids":"88287,88288"}`, 680639, 628942, AlbumIDs{88287, 88288}},
+		{`{"id":"680639","artist_id":"628942","album_ids":[88287]}`, 680639, 628942, AlbumIDs{88287}},
+		{`{"id":null,"artist_id":"","album_ids":""}`, 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		var hit LyricsHit
+		if err := json.Unmarshal([]byte(tt.json), &hit); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.json, err)
+			continue
+		}
+		if hit.ID != tt.track || hit.ArtistID != tt.artist || !reflect.DeepEqual(hit.AlbumIDs, tt.albums) {
+			t.Errorf("Unmarshal(%s) = %v, %v, %v", tt.json, hit.ID, hit.ArtistID, hit.AlbumIDs)
+		}
+	}
+}
+
+func TestGetByTypedID(t *testing.T) {
+	var paths []string
+	s := testSession(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		return jsonResponse(http.StatusOK, `{}`), nil
+	})
+
+	hit := LyricsHit{ID: 680639, ArtistID: 628942, AlbumIDs: AlbumIDs{88287}}
+	if _, err := s.GetTrack(hit.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetArtist(hit.ArtistID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetAlbum(hit.AlbumIDs[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"/lyrics/track/680639", "/lyrics/artist/628942", "/lyrics/album/88287"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("requested %q, want %q", paths, want)
+	}
+}
@@ -0,0 +1,138 @@
+// Package render turns KSoft API responses into Discord embeds. The embed
+// types marshal to the JSON Discord expects, so they can be sent with any
+// Discord library or plain HTTP.
+package render
+
+import "unicode/utf8"
+
+// Discord embed limits, see https://discord.com/developers/docs/resources/channel#embed-limits
+const (
+	LimitTitle       = 256
+	LimitDescription = 4096
+	LimitFields      = 25
+	LimitFieldName   = 256
+	LimitFieldValue  = 1024
+	LimitFooter      = 2048
+	LimitAuthorName  = 256
+	LimitTotal       = 6000
+)
+
+// Embed colours.
+const (
+	ColorDefault = 0x2F3136
+	ColorReddit  = 0xFF4500
+	ColorWikiHow = 0x93B874
+	ColorKumo    = 0x3498DB
+	ColorLyrics  = 0x9B59B6
+	ColorBanned  = 0xE74C3C
+	ColorClean   = 0x2ECC71
+)
+
+type Embed struct {
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	URL         string          `json:"url,omitempty"`
+	Timestamp   string          `json:"timestamp,omitempty"`
+	Color       int             `json:"color,omitempty"`
+	Footer      *EmbedFooter    `json:"footer,omitempty"`
+	Image       *EmbedImage     `json:"image,omitempty"`
+	Thumbnail   *EmbedThumbnail `json:"thumbnail,omitempty"`
+	Author      *EmbedAuthor    `json:"author,omitempty"`
+	Fields      []*EmbedField   `json:"fields,omitempty"`
+}
+
+type EmbedFooter struct {
+	Text    string `json:"text"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+type EmbedImage struct {
+	URL string `json:"url"`
+}
+
+type EmbedThumbnail struct {
+	URL string `json:"url"`
+}
+
+type EmbedAuthor struct {
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// AddField appends a field, skipping empty values Discord would reject.
+func (e *Embed) AddField(name, value string, inline bool) *Embed {
+	if name == "" || value == "" {
+		return e
+	}
+	e.Fields = append(e.Fields, &EmbedField{Name: name, Value: value, Inline: inline})
+	return e
+}
+
+// Limit truncates the embed to Discord's limits. Text that does not fit is
+// cut with an ellipsis, extra fields are dropped and, if the embed is still
+// too large in total, the description is shortened.
+func (e *Embed) Limit() *Embed {
+	e.Title = truncate(e.Title, LimitTitle)
+	e.Description = truncate(e.Description, LimitDescription)
+	if e.Footer != nil {
+		e.Footer.Text = truncate(e.Footer.Text, LimitFooter)
+	}
+	if e.Author != nil {
+		e.Author.Name = truncate(e.Author.Name, LimitAuthorName)
+	}
+	if len(e.Fields) > LimitFields {
+		e.Fields = e.Fields[:LimitFields]
+	}
+	for _, f := range e.Fields {
+		f.Name = truncate(f.Name, LimitFieldName)
+		f.Value = truncate(f.Value, LimitFieldValue)
+	}
+
+	for over := e.length() - LimitTotal; over > 0; over = e.length() - LimitTotal {
+		if n := utf8.RuneCountInString(e.Description); n > 0 {
+			keep := n - over
+			if keep < 0 {
+				keep = 0
+			}
+			e.Description = truncate(e.Description, keep)
+			continue
+		}
+		if len(e.Fields) == 0 {
+			break
+		}
+		e.Fields = e.Fields[:len(e.Fields)-1]
+	}
+	return e
+}
+
+// length counts the characters Discord adds up for the total embed limit.
+func (e *Embed) length() int {
+	n := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	if e.Footer != nil {
+		n += utf8.RuneCountInString(e.Footer.Text)
+	}
+	if e.Author != nil {
+		n += utf8.RuneCountInString(e.Author.Name)
+	}
+	for _, f := range e.Fields {
+		n += utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+	}
+	return n
+}
+
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	if max <= 1 {
+		return string([]rune(s)[:max])
+	}
+	return string([]rune(s)[:max-1]) + "…"
+}
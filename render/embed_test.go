@@ -0,0 +1,154 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		max  int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exact", 5, "exact"},
+		{"too long", 5, "too …"},
+		{"éééééé", 3, "éé…"},
+		{"abc", 1, "a"},
+		{"abc", 0, ""},
+	}
+
+	for _, tt := range tests {
+		if got := truncate(tt.s, tt.max); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+		}
+	}
+}
+
+func fields(n int, name, value string) []*EmbedField {
+	fs := make([]*EmbedField, n)
+	for i := range fs {
+		fs[i] = &EmbedField{Name: name, Value: value}
+	}
+	return fs
+}
+
+func TestEmbedLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		embed       Embed
+		title       int
+		description int
+		fields      int
+		fieldName   int
+		fieldValue  int
+	}{
+		{
+			name:        "within limits",
+			embed:       Embed{Title: "title", Description: "description", Fields: fields(2, "name", "value")},
+			title:       5,
+			description: 11,
+			fields:      2,
+			fieldName:   4,
+			fieldValue:  5,
+		},
+		{
+			name:  "long title",
+			embed: Embed{Title: strings.Repeat("t", 300)},
+			title: LimitTitle,
+		},
+		{
+			name:        "long multi-byte description",
+			embed:       Embed{Description: strings.Repeat("é", 5000)},
+			description: LimitDescription,
+		},
+		{
+			name:       "long fields",
+			embed:      Embed{Fields: fields(2, strings.Repeat("n", 300), strings.Repeat("v", 2000))},
+			fields:     2,
+			fieldName:  LimitFieldName,
+			fieldValue: LimitFieldValue,
+		},
+		{
+			name:       "too many fields",
+			embed:      Embed{Fields: fields(30, "name", "value")},
+			fields:     LimitFields,
+			fieldName:  4,
+			fieldValue: 5,
+		},
+		{
+			name: "total shortens the description first",
+			embed: Embed{
+				Title:       strings.Repeat("t", 100),
+				Description: strings.Repeat("d", 4000),
+				Fields:      fields(3, "name", strings.Repeat("v", 996)),
+			},
+			title:       100,
+			description: LimitTotal - 100 - 3*1000,
+			fields:      3,
+			fieldName:   4,
+			fieldValue:  996,
+		},
+		{
+			name: "total drops fields without a description",
+			embed: Embed{
+				Title:  strings.Repeat("t", 256),
+				Fields: fields(25, strings.Repeat("n", 24), strings.Repeat("v", 1000)),
+			},
+			title:      256,
+			fields:     5,
+			fieldName:  24,
+			fieldValue: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.embed
+			e.Limit()
+
+			if n := utf8.RuneCountInString(e.Title); n != tt.title {
+				t.Errorf("title has %d characters, want %d", n, tt.title)
+			}
+			if n := utf8.RuneCountInString(e.Description); n != tt.description {
+				t.Errorf("description has %d characters, want %d", n, tt.description)
+			}
+			if len(e.Fields) != tt.fields {
+				t.Fatalf("%d fields, want %d", len(e.Fields), tt.fields)
+			}
+			for _, f := range e.Fields {
+				if n := utf8.RuneCountInString(f.Name); n != tt.fieldName {
+					t.Errorf("field name has %d characters, want %d", n, tt.fieldName)
+				}
+				if n := utf8.RuneCountInString(f.Value); n != tt.fieldValue {
+					t.Errorf("field value has %d characters, want %d", n, tt.fieldValue)
+				}
+			}
+			if n := e.length(); n > LimitTotal {
+				t.Errorf("embed has %d characters, over %d", n, LimitTotal)
+			}
+		})
+	}
+}
+
+func TestEmbedLimitEllipsis(t *testing.T) {
+	e := (&Embed{Title: strings.Repeat("t", 300), Footer: &EmbedFooter{Text: strings.Repeat("f", 3000)}}).Limit()
+
+	if !strings.HasSuffix(e.Title, "…") {
+		t.Errorf("truncated title %q does not end with an ellipsis", e.Title[len(e.Title)-10:])
+	}
+	if n := utf8.RuneCountInString(e.Footer.Text); n != LimitFooter {
+		t.Errorf("footer has %d characters, want %d", n, LimitFooter)
+	}
+}
+
+func TestAddFieldSkipsEmpty(t *testing.T) {
+	e := &Embed{}
+	e.AddField("name", "", false).AddField("", "value", false).AddField("name", "value", true)
+
+	if len(e.Fields) != 1 || !e.Fields[0].Inline {
+		t.Errorf("fields = %+v, want the one inline field", e.Fields)
+	}
+}
@@ -0,0 +1,296 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	ksoftgo "gopkg.in/KSoft-Si/KSoftgo.v2"
+)
+
+// Reddit renders a reddit post with its image, subreddit and votes.
+func Reddit(r ksoftgo.Reddit) *Embed {
+	e := &Embed{
+		Title:     r.Title,
+		URL:       r.Source,
+		Color:     ColorReddit,
+		Timestamp: timestamp(r.CreatedAt),
+		Footer: &EmbedFooter{
+			Text: fmt.Sprintf("👍 %d | 💬 %d | %s", r.Upvotes, r.Comments, r.Subreddit),
+		},
+	}
+	if r.Author != "" {
+		e.Author = &EmbedAuthor{Name: r.Author}
+	}
+	if r.ImageURL != "" {
+		e.Image = &EmbedImage{URL: r.ImageURL}
+	}
+	return e.Limit()
+}
+
+// Image renders a random image.
+func Image(i ksoftgo.Image) *Embed {
+	e := &Embed{
+		Color:  ColorDefault,
+		Image:  &EmbedImage{URL: i.URL},
+		Footer: &EmbedFooter{Text: join(" | ", i.Tag, i.Snowflake)},
+	}
+	return e.Limit()
+}
+
+// WikiHow renders a WikiHow image linking to its article.
+func WikiHow(w ksoftgo.WikiHowImage) *Embed {
+	e := &Embed{
+		Title:  w.Title,
+		URL:    w.ArticleURL,
+		Color:  ColorWikiHow,
+		Image:  &EmbedImage{URL: w.URL},
+		Footer: &EmbedFooter{Text: "wikiHow"},
+	}
+	return e.Limit()
+}
+
+// Weather renders a weather report, coloured by its icon.
+func Weather(w ksoftgo.Weather) *Embed {
+	d := w.Data
+	e := &Embed{
+		Title:       join(" ", d.Emoji(), d.Location.Address),
+		Description: d.Summary,
+		Color:       weatherColor(d.Icon),
+		Timestamp:   timestamp(d.Time),
+		Footer:      &EmbedFooter{Text: "Powered by KSoft.Si Kumo"},
+	}
+	if d.IconURL != "" {
+		e.Thumbnail = &EmbedThumbnail{URL: d.IconURL}
+	}
+	e.AddField("Temperature", d.Temp().Format(d.Units), true)
+	e.AddField("Feels like", d.ApparentTemp().Format(d.Units), true)
+	e.AddField("Humidity", formatFloat(d.Humidity*100)+"%", true)
+	e.AddField("Wind", d.Wind().Format(d.Units)+" "+d.WindDirection(), true)
+	e.AddField("Precipitation", formatFloat(d.PrecipProbability*100)+"%", true)
+	e.AddField("UV index", strconv.Itoa(d.UvIndex)+" ("+d.UVCategory()+")", true)
+	for _, a := range d.Alerts {
+		e.AddField("⚠ "+a.Title, a.Description, false)
+	}
+	return e.Limit()
+}
+
+// GeoIP renders the location of an IP address.
+func GeoIP(g ksoftgo.GeoIP) *Embed {
+	d := g.Data
+	e := &Embed{
+		Title:  join(", ", d.City, d.Region, d.CountryName),
+		URL:    d.Apis.Googlemaps,
+		Color:  ColorKumo,
+		Footer: &EmbedFooter{Text: "Powered by KSoft.Si Kumo"},
+	}
+	e.AddField("Coordinates", formatFloat(d.Latitude)+", "+formatFloat(d.Longitude), true)
+	e.AddField("Time zone", d.TimeZone, true)
+	e.AddField("Postal code", d.PostalCode, true)
+	e.AddField("Continent", d.ContinentName, true)
+	return e.Limit()
+}
+
+// Currency renders a currency conversion.
+func Currency(c ksoftgo.Currency) *Embed {
+	e := &Embed{
+		Title:       "Currency conversion",
+		Description: c.Pretty,
+		Color:       ColorKumo,
+		Footer:      &EmbedFooter{Text: "Powered by KSoft.Si Kumo"},
+	}
+	return e.Limit()
+}
+
+// LyricsSearch renders the best hit with its lyrics and lists the others.
+func LyricsSearch(l ksoftgo.LyricsSearch) *Embed {
+	e := &Embed{
+		Color:  ColorLyrics,
+		Footer: &EmbedFooter{Text: "Lyrics provided by KSoft.Si"},
+	}
+	if len(l.Data) == 0 {
+		e.Title = "No lyrics found"
+		return e.Limit()
+	}
+
+	best := l.Data[0]
+	e.Title = best.Artist + " - " + best.Name
+	e.URL = best.URL
+	e.Description = best.Lyrics
+	if best.AlbumArt != "" {
+		e.Thumbnail = &EmbedThumbnail{URL: best.AlbumArt}
+	}
+	for _, hit := range l.Data[1:] {
+		album := hit.Album
+		if hit.AlbumYear != 0 {
+			album = join(" ", album, "("+strconv.Itoa(hit.AlbumYear)+")")
+		}
+		e.AddField(hit.Artist+" - "+hit.Name, album, false)
+	}
+	return e.Limit()
+}
+
+// BanInfo renders a ban, red while it is active and green otherwise.
+func BanInfo(b ksoftgo.BanInfo) *Embed {
+	e := &Embed{
+		Title:     b.Name + "#" + b.Discriminator,
+		Color:     ColorClean,
+		Timestamp: timestamp(b.Timestamp),
+		Footer:    &EmbedFooter{Text: "User ID " + b.ID},
+	}
+	if b.IsBanActive {
+		e.Color = ColorBanned
+	}
+	e.AddField("Reason", b.Reason, false)
+	e.AddField("Proof", b.Proof, false)
+	e.AddField("Moderator", b.ModeratorID, true)
+	e.AddField("Active", strconv.FormatBool(b.IsBanActive), true)
+	e.AddField("Appealable", strconv.FormatBool(b.CanBeAppealed), true)
+	return e.Limit()
+}
+
+// Template customises the embed of one response type. Title, Description
+// and Footer are text/template sources executed with the response as data;
+// empty ones keep the default text. Customize, if set, runs last.
+type Template struct {
+	Title       string
+	Description string
+	Footer      string
+	Color       int
+	Customize   func(e *Embed, v interface{})
+}
+
+// Renderer renders responses with per type templates on top of the
+// defaults of the package level functions.
+type Renderer struct {
+	Reddit       Template
+	Image        Template
+	WikiHow      Template
+	Weather      Template
+	GeoIP        Template
+	Currency     Template
+	LyricsSearch Template
+	BanInfo      Template
+}
+
+// Render renders any of the supported response types.
+func (r *Renderer) Render(v interface{}) (*Embed, error) {
+	var e *Embed
+	var t Template
+	switch v := v.(type) {
+	case ksoftgo.Reddit:
+		e, t = Reddit(v), r.Reddit
+	case ksoftgo.Image:
+		e, t = Image(v), r.Image
+	case ksoftgo.WikiHowImage:
+		e, t = WikiHow(v), r.WikiHow
+	case ksoftgo.Weather:
+		e, t = Weather(v), r.Weather
+	case ksoftgo.GeoIP:
+		e, t = GeoIP(v), r.GeoIP
+	case ksoftgo.Currency:
+		e, t = Currency(v), r.Currency
+	case ksoftgo.LyricsSearch:
+		e, t = LyricsSearch(v), r.LyricsSearch
+	case ksoftgo.BanInfo:
+		e, t = BanInfo(v), r.BanInfo
+	default:
+		return nil, fmt.Errorf("render: unsupported type %T", v)
+	}
+
+	if err := t.apply(e, v); err != nil {
+		return nil, err
+	}
+	return e.Limit(), nil
+}
+
+func (t Template) apply(e *Embed, v interface{}) (err error) {
+	if e.Title, err = execute(t.Title, e.Title, v); err != nil {
+		return
+	}
+	if e.Description, err = execute(t.Description, e.Description, v); err != nil {
+		return
+	}
+	if t.Footer != "" {
+		if e.Footer == nil {
+			e.Footer = &EmbedFooter{}
+		}
+		if e.Footer.Text, err = execute(t.Footer, e.Footer.Text, v); err != nil {
+			return
+		}
+	}
+	if t.Color != 0 {
+		e.Color = t.Color
+	}
+	if t.Customize != nil {
+		t.Customize(e, v)
+	}
+	return
+}
+
+func execute(src, fallback string, v interface{}) (string, error) {
+	if src == "" {
+		return fallback, nil
+	}
+
+	tmpl, err := template.New("embed").Parse(src)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, v); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func weatherColor(icon string) int {
+	switch icon {
+	case "clear-day":
+		return 0xF1C40F
+	case "clear-night":
+		return 0x2C3E50
+	case "rain":
+		return 0x3498DB
+	case "snow", "sleet":
+		return 0xECF0F1
+	case "wind":
+		return 0x1ABC9C
+	case "fog", "cloudy":
+		return 0x95A5A6
+	case "partly-cloudy-day":
+		return 0xF5B041
+	case "partly-cloudy-night":
+		return 0x566573
+	case "thunderstorm":
+		return 0x8E44AD
+	}
+	return ColorKumo
+}
+
+func timestamp(t ksoftgo.Timestamp) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatFloat formats f with at most one decimal.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*10)/10, 'f', -1, 64)
+}
+
+// join joins the non-empty parts with sep.
+func join(sep string, parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,117 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	ksoftgo "gopkg.in/KSoft-Si/KSoftgo.v2"
+)
+
+func TestRendererTemplates(t *testing.T) {
+	post := ksoftgo.Reddit{Title: "A meme", Subreddit: "r/memes", Upvotes: 42, Author: "u/someone"}
+
+	tests := []struct {
+		name     string
+		renderer Renderer
+		value    interface{}
+		check    func(t *testing.T, e *Embed)
+	}{
+		{
+			name:     "defaults",
+			renderer: Renderer{},
+			value:    post,
+			check: func(t *testing.T, e *Embed) {
+				if e.Title != "A meme" || e.Color != ColorReddit {
+					t.Errorf("title %q colour %#x, want the defaults", e.Title, e.Color)
+				}
+			},
+		},
+		{
+			name: "title, footer and colour",
+			renderer: Renderer{Reddit: Template{
+				Title:  "{{.Subreddit}}: {{.Title}}",
+				Footer: "{{.Upvotes}} upvotes",
+				Color:  0x123456,
+			}},
+			value: post,
+			check: func(t *testing.T, e *Embed) {
+				if e.Title != "r/memes: A meme" {
+					t.Errorf("title = %q", e.Title)
+				}
+				if e.Footer == nil || e.Footer.Text != "42 upvotes" {
+					t.Errorf("footer = %+v", e.Footer)
+				}
+				if e.Color != 0x123456 {
+					t.Errorf("colour = %#x", e.Color)
+				}
+				if e.Author == nil || e.Author.Name != "u/someone" {
+					t.Errorf("author = %+v, want the default kept", e.Author)
+				}
+			},
+		},
+		{
+			name:     "footer on an embed without one",
+			renderer: Renderer{WikiHow: Template{Footer: "{{.Title}}"}},
+			value:    ksoftgo.WikiHowImage{Title: "How to test"},
+			check: func(t *testing.T, e *Embed) {
+				if e.Footer == nil || e.Footer.Text != "How to test" {
+					t.Errorf("footer = %+v", e.Footer)
+				}
+			},
+		},
+		{
+			name: "customize runs last",
+			renderer: Renderer{Currency: Template{
+				Description: "{{.Pretty}}!",
+				Customize: func(e *Embed, v interface{}) {
+					e.AddField("Value", e.Description, false)
+				},
+			}},
+			value: ksoftgo.Currency{Pretty: "1.50 EUR"},
+			check: func(t *testing.T, e *Embed) {
+				if len(e.Fields) != 1 || e.Fields[0].Value != "1.50 EUR!" {
+					t.Errorf("fields = %+v", e.Fields)
+				}
+			},
+		},
+		{
+			name:     "template output is limited",
+			renderer: Renderer{Reddit: Template{Title: "{{.Title}} {{.Title}}"}},
+			value:    ksoftgo.Reddit{Title: strings.Repeat("x", 200)},
+			check: func(t *testing.T, e *Embed) {
+				if n := utf8.RuneCountInString(e.Title); n != LimitTitle {
+					t.Errorf("title has %d characters, want %d", n, LimitTitle)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := tt.renderer.Render(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, e)
+		})
+	}
+}
+
+func TestRendererErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer Renderer
+		value    interface{}
+	}{
+		{"unsupported type", Renderer{}, "a string"},
+		{"invalid template", Renderer{Image: Template{Title: "{{.Tag"}}, ksoftgo.Image{}},
+		{"failing template", Renderer{Image: Template{Title: "{{.Missing}}"}}, ksoftgo.Image{}},
+	}
+
+	for _, tt := range tests {
+		if e, err := tt.renderer.Render(tt.value); err == nil {
+			t.Errorf("%s: Render = %+v, want error", tt.name, e)
+		}
+	}
+}
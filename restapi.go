@@ -2,6 +2,7 @@ package ksoftgo
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io/ioutil"
 	"log"
@@ -79,12 +80,19 @@ func (s *KSession) PostForm(urlStr string, data url.Values) (err error) {
 }
 
 func (s *KSession) request(method, urlStr string, b []byte) (response []byte, err error) {
+	return s.requestWithContext(context.Background(), method, urlStr, b)
+}
+
+func (s *KSession) requestWithContext(ctx context.Context, method, urlStr string, b []byte) (response []byte, err error) {
 	if s.Debug {
 		log.Printf("REQUEST %8s :: %s\n", method, urlStr)
 		log.Printf("REQUEST  PAYLOAD :: [%s]\n", string(b))
 	}
 
-	req, err := http.NewRequest(method, urlStr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, bytes.NewBuffer(b))
+	if err != nil {
+		return
+	}
 
 	if s.Token != "" {
 		req.Header.Set("authorization", "Bearer "+s.Token)
@@ -103,6 +111,9 @@ func (s *KSession) request(method, urlStr string, b []byte) (response []byte, er
 	}
 
 	resp, err := s.Client.Do(req)
+	if err != nil {
+		return
+	}
 
 	defer func() {
 		err2 := resp.Body.Close()
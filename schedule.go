@@ -0,0 +1,167 @@
+package ksoftgo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule decides when a scheduled job runs next.
+type Schedule interface {
+	// Next returns the first run time strictly after t.
+	Next(t time.Time) time.Time
+}
+
+type intervalSchedule time.Duration
+
+// Every returns a schedule running every d, aligned to multiples of d since
+// the unix epoch so restarts keep the same slots. Every(7*time.Hour) runs at
+// 07:00 UTC on January 1st 1970 and every 7 hours from there.
+func Every(d time.Duration) Schedule {
+	if d < time.Second {
+		d = time.Second
+	}
+	return intervalSchedule(d)
+}
+
+func (i intervalSchedule) Next(t time.Time) time.Time {
+	// time.Truncate aligns to the zero time, not the unix epoch.
+	d := int64(i)
+	n := t.UnixNano()
+	r := n % d
+	if r < 0 {
+		r += d
+	}
+	return time.Unix(0, n-r+d).In(t.Location())
+}
+
+// cronSchedule is a parsed five field cron expression. Each field is a bit
+// set of the allowed values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// anyDay is set when day-of-month or day-of-week is *. Otherwise a day
+	// matching either field is a match, like in cron.
+	anyDay bool
+	loc    *time.Location
+}
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = [5]cronField{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// ParseCron parses a standard five field cron expression ("minute hour
+// day-of-month month day-of-week") evaluated in loc, or UTC when loc is nil.
+// Fields accept *, single values, ranges (1-5), lists (1,15) and steps (*/10).
+// Example:
+//		schedule, err := ksoftgo.ParseCron("0 * * * *", nil)
+func ParseCron(expr string, loc *time.Location) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	var sets [5]uint64
+	for i, f := range fields {
+		set, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+	// Sunday may be written as 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &cronSchedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		anyDay: strings.HasPrefix(fields[2], "*") || strings.HasPrefix(fields[4], "*"),
+		loc:    loc,
+	}, nil
+}
+
+func parseCronField(field string, bounds cronField) (set uint64, err error) {
+	max := bounds.max
+	if bounds == cronFields[4] {
+		max = 7
+	}
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			part = part[:i]
+		}
+
+		lo, hi := bounds.min, bounds.max
+		if part != "*" {
+			ends := strings.SplitN(part, "-", 2)
+			if lo, err = strconv.Atoi(ends[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			hi = lo
+			if len(ends) == 2 {
+				if hi, err = strconv.Atoi(ends[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			}
+		}
+		if lo < bounds.min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value %q out of range", part)
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	t = t.In(c.loc).Truncate(time.Minute).Add(time.Minute)
+	// No expression needs more than a few years to match, Feb 29th included.
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.loc)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.anyDay {
+		return dom && dow
+	}
+	return dom || dow
+}
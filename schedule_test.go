@@ -0,0 +1,175 @@
+package ksoftgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEveryNext(t *testing.T) {
+	epoch := time.Unix(0, 0).UTC()
+	base := time.Date(2024, time.January, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		every time.Duration
+		from  time.Time
+		want  time.Time
+	}{
+		{"epoch aligned", 7 * time.Hour, epoch, epoch.Add(7 * time.Hour)},
+		{"hourly", time.Hour, base, base.Add(30 * time.Minute)},
+		{"on a slot", time.Hour, base.Add(30 * time.Minute), base.Add(90 * time.Minute)},
+		{"before epoch", 7 * time.Hour, epoch.Add(-time.Second), epoch},
+		{"clamped to a second", time.Millisecond, base, base.Add(time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Every(tt.every).Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Every(%v).Next(%v) = %v, want %v", tt.every, tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// January 1st 2024 is a Monday.
+	date := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2024, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"hourly", "0 * * * *", date(1, 1, 10, 30), date(1, 1, 11, 0)},
+		{"strictly after", "30 10 * * *", date(1, 1, 10, 30), date(1, 2, 10, 30)},
+		{"minute step", "*/15 * * * *", date(1, 1, 10, 31), date(1, 1, 10, 45)},
+		{"range step", "0 9-17/4 * * *", date(1, 1, 10, 0), date(1, 1, 13, 0)},
+		{"list", "0 8,20 * * *", date(1, 1, 9, 0), date(1, 1, 20, 0)},
+		{"sunday as 0", "0 0 * * 0", date(1, 1, 0, 0), date(1, 7, 0, 0)},
+		{"sunday as 7", "0 0 * * 7", date(1, 1, 0, 0), date(1, 7, 0, 0)},
+		{"day of week only", "0 0 * * 5", date(1, 1, 0, 0), date(1, 5, 0, 0)},
+		{"day of month only", "0 0 13 * *", date(1, 1, 0, 0), date(1, 13, 0, 0)},
+		{"day of month or week, week first", "0 0 13 * 5", date(1, 1, 0, 0), date(1, 5, 0, 0)},
+		{"day of month or week, month first", "0 0 13 * 5", date(1, 12, 0, 0), date(1, 13, 0, 0)},
+		{"month", "0 0 1 6 *", date(1, 1, 0, 0), date(6, 1, 0, 0)},
+		{"leap day", "0 0 29 2 *", date(3, 1, 0, 0), time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", date(1, 1, 0, 0), time.Time{}},
+		{"never in april", "0 0 31 4 *", date(1, 1, 0, 0), time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr, nil)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("ParseCron(%q).Next(%v) = %v, want %v", tt.expr, tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	schedule, err := ParseCron("0 9 * * *", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2024, time.January, 1, 6, 0, 0, 0, time.UTC)
+	want := time.Date(2024, time.January, 1, 7, 0, 0, 0, time.UTC)
+	if got := schedule.Next(from); !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", from, got, want)
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := ParseCron(expr, nil); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+type memorySchedulerStore struct {
+	lastRun map[string]time.Time
+	err     error
+}
+
+func (m *memorySchedulerStore) LastRun(jobID string) (time.Time, error) {
+	return m.lastRun[jobID], m.err
+}
+
+func (m *memorySchedulerStore) SetLastRun(jobID string, t time.Time) error {
+	m.lastRun[jobID] = t
+	return m.err
+}
+
+func TestSchedulerAddMissedSlot(t *testing.T) {
+	now := time.Now()
+	schedule := Every(time.Hour)
+	upcoming := schedule.Next(now)
+
+	tests := []struct {
+		name    string
+		lastRun time.Time
+		want    time.Time
+	}{
+		{"never run", time.Time{}, upcoming},
+		{"ran this slot", upcoming.Add(-time.Hour), upcoming},
+		{"missed a slot", upcoming.Add(-3 * time.Hour), upcoming.Add(-2 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(nil, nil)
+			s.Store = &memorySchedulerStore{lastRun: map[string]time.Time{"job": tt.lastRun}}
+
+			job := Job{ID: "job", Target: "channel", Schedule: schedule, Source: SourceMeme()}
+			if err := s.Add(job); err != nil {
+				t.Fatal(err)
+			}
+			if got := s.jobs["job"].next; !got.Equal(tt.want) {
+				t.Errorf("next run = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulerAddErrors(t *testing.T) {
+	job := Job{ID: "job", Target: "channel", Schedule: Every(time.Hour), Source: SourceMeme()}
+
+	s := NewScheduler(nil, nil)
+	if err := s.Add(Job{ID: "job"}); !errors.Is(err, ErrInvalidJob) {
+		t.Errorf("Add(invalid) = %v, want ErrInvalidJob", err)
+	}
+	if err := s.Add(job); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(job); !errors.Is(err, ErrJobExists) {
+		t.Errorf("Add(duplicate) = %v, want ErrJobExists", err)
+	}
+
+	storeErr := errors.New("store down")
+	s = NewScheduler(nil, nil)
+	s.Store = &memorySchedulerStore{err: storeErr}
+	if err := s.Add(job); !errors.Is(err, storeErr) {
+		t.Errorf("Add with failing store = %v, want %v", err, storeErr)
+	}
+}
@@ -0,0 +1,235 @@
+package ksoftgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrJobExists   = errors.New("job already scheduled")
+	ErrInvalidJob  = errors.New("job needs an ID, a target, a schedule and a source")
+	ErrNSFWRefused = errors.New("NSFW post refused by job")
+)
+
+// Sink delivers scheduled posts, e.g. to a Discord channel.
+type Sink interface {
+	Deliver(ctx context.Context, target string, post MediaSource) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, target string, post MediaSource) error
+
+func (f SinkFunc) Deliver(ctx context.Context, target string, post MediaSource) error {
+	return f(ctx, target, post)
+}
+
+// SchedulerStore persists the last run of every job, so a restarted
+// scheduler neither repeats nor skips a slot.
+type SchedulerStore interface {
+	LastRun(jobID string) (time.Time, error)
+	SetLastRun(jobID string, t time.Time) error
+}
+
+// PostSource fetches the post for a job through the scheduler's feed, so
+// posts are not repeated for the same target.
+type PostSource func(f *Feed, target string) (MediaSource, error)
+
+// SourceMeme posts random memes.
+func SourceMeme() PostSource {
+	return func(f *Feed, target string) (MediaSource, error) { return f.RandomMeme(target) }
+}
+
+// SourceAww posts random aww pictures.
+func SourceAww() PostSource {
+	return func(f *Feed, target string) (MediaSource, error) { return f.RandomAww(target) }
+}
+
+// SourceReddit posts random posts of a subreddit.
+func SourceReddit(param ParamRandomReddit) PostSource {
+	return func(f *Feed, target string) (MediaSource, error) { return f.RandomReddit(target, param) }
+}
+
+// SourceImage posts random images of a tag.
+func SourceImage(tag ParamRandomImage) PostSource {
+	return func(f *Feed, target string) (MediaSource, error) { return f.RandomImage(target, tag) }
+}
+
+// Job posts from Source to Target on Schedule.
+type Job struct {
+	ID       string
+	Target   string
+	Schedule Schedule
+	Source   PostSource
+	// AllowNSFW lets the job post NSFW content. Without it NSFW posts are
+	// refused even if the session's content policy allows them.
+	AllowNSFW bool
+}
+
+// Scheduler runs jobs posting random memes and images to their targets.
+type Scheduler struct {
+	Feed  *Feed
+	Sink  Sink
+	Store SchedulerStore
+	// OnError, if set, is called when a run of a job fails.
+	OnError func(job Job, err error)
+
+	mu   sync.Mutex
+	jobs map[string]*scheduledJob
+	wake chan struct{}
+}
+
+type scheduledJob struct {
+	job  Job
+	next time.Time
+}
+
+// NewScheduler creates a scheduler fetching posts through feed and
+// delivering them to sink.
+func NewScheduler(feed *Feed, sink Sink) *Scheduler {
+	return &Scheduler{
+		Feed: feed,
+		Sink: sink,
+		jobs: make(map[string]*scheduledJob),
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Add schedules a job. Its first run follows the last run recorded in the
+// store; a slot missed while the scheduler was down runs once right away.
+func (s *Scheduler) Add(job Job) error {
+	if job.ID == "" || job.Target == "" || job.Schedule == nil || job.Source == nil {
+		return ErrInvalidJob
+	}
+
+	now := time.Now()
+	next := job.Schedule.Next(now)
+	if s.Store != nil {
+		last, err := s.Store.LastRun(job.ID)
+		if err != nil {
+			return err
+		}
+		if !last.IsZero() {
+			if missed := job.Schedule.Next(last); missed.Before(next) {
+				next = missed
+			}
+		}
+	}
+
+	s.mu.Lock()
+	if _, ok := s.jobs[job.ID]; ok {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrJobExists, job.ID)
+	}
+	s.jobs[job.ID] = &scheduledJob{job: job, next: next}
+	s.mu.Unlock()
+
+	s.notify()
+	return nil
+}
+
+// Remove unschedules a job.
+func (s *Scheduler) Remove(id string) {
+	s.mu.Lock()
+	delete(s.jobs, id)
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+// Run runs due jobs until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		wait := time.Hour
+		if next, ok := s.nextRun(); ok {
+			wait = time.Until(next)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-s.wake:
+			timer.Stop()
+			continue
+		case <-timer.C:
+		}
+
+		for _, job := range s.due(time.Now()) {
+			if err := s.run(ctx, job); err != nil && s.OnError != nil {
+				s.OnError(job.job, err)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) nextRun() (next time.Time, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, j := range s.jobs {
+		if j.next.IsZero() {
+			continue
+		}
+		if !ok || j.next.Before(next) {
+			next, ok = j.next, true
+		}
+	}
+	return
+}
+
+// due returns the jobs to run at now and moves them to their next slot.
+func (s *Scheduler) due(now time.Time) (jobs []scheduledJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, j := range s.jobs {
+		if j.next.IsZero() || j.next.After(now) {
+			continue
+		}
+		jobs = append(jobs, *j)
+		j.next = j.job.Schedule.Next(now)
+	}
+	return
+}
+
+// run posts once for a job. The slot is recorded before delivery, so a
+// crash during delivery skips the post rather than posting it twice.
+func (s *Scheduler) run(ctx context.Context, j scheduledJob) error {
+	if s.Store != nil {
+		if err := s.Store.SetLastRun(j.job.ID, j.next); err != nil {
+			return err
+		}
+	}
+
+	post, err := j.job.Source(s.Feed, j.job.Target)
+	if err != nil {
+		return err
+	}
+	if !j.job.AllowNSFW && isNSFW(post) {
+		return ErrNSFWRefused
+	}
+	return s.Sink.Deliver(ctx, j.job.Target, post)
+}
+
+func isNSFW(post MediaSource) bool {
+	switch p := post.(type) {
+	case Reddit:
+		return p.NSFW
+	case Image:
+		return p.NSFW
+	case WikiHowImage:
+		return p.NSFW
+	}
+	return false
+}
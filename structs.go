@@ -18,36 +18,45 @@ type KSession struct {
 	UserAgent      string
 	MaxRestRetries int
 	RetryAfter     time.Duration
+	ContentPolicy  ContentPolicy
+
+	// AllowedSubreddits, when not empty, are the only subreddits random
+	// reddit posts may come from. DeniedSubreddits are always refused.
+	AllowedSubreddits []string
+	DeniedSubreddits  []string
 }
 
 // RESPONSES
 
+type ArtistRef struct {
+	ID   ArtistID `json:"id"`
+	Name string   `json:"name"`
+}
+
+type AlbumRef struct {
+	ID   AlbumID `json:"id"`
+	Name string  `json:"name"`
+	Year int     `json:"year"`
+}
+
+type TrackRef struct {
+	ID   TrackID `json:"id"`
+	Name string  `json:"name"`
+}
+
 type Album struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	Year   int    `json:"year"`
-	Artist struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} `json:"artist"`
-	Tracks []struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} `json:"tracks"`
+	ID     AlbumID    `json:"id"`
+	Name   string     `json:"name"`
+	Year   int        `json:"year"`
+	Artist ArtistRef  `json:"artist"`
+	Tracks []TrackRef `json:"tracks"`
 }
 
 type Artist struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	Albums []struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-		Year int    `json:"year"`
-	} `json:"albums"`
-	Tracks []struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} `json:"tracks"`
+	ID     ArtistID   `json:"id"`
+	Name   string     `json:"name"`
+	Albums []AlbumRef `json:"albums"`
+	Tracks []TrackRef `json:"tracks"`
 }
 
 type APIErrorMessage struct {
@@ -69,13 +78,15 @@ type WikiHowImage struct {
 	ArticleURL string `json:"article_url"`
 }
 
+type TagModel struct {
+	Name string `json:"name"`
+	Nsfw bool   `json:"nsfw"`
+}
+
 type Tags struct {
-	Models []struct {
-		Name string `json:"name"`
-		Nsfw bool   `json:"nsfw"`
-	} `json:"models"`
-	Tags     []string `json:"tags"`
-	NsfwTags []string `json:"nsfw_tags"`
+	Models   []TagModel `json:"models"`
+	Tags     []string   `json:"tags"`
+	NsfwTags []string   `json:"nsfw_tags"`
 }
 
 type BanCheck struct {
@@ -83,19 +94,33 @@ type BanCheck struct {
 }
 
 type BanInfo struct {
-	ID            string      `json:"id"`
-	Name          string      `json:"name"`
-	Discriminator string      `json:"discriminator"`
-	ModeratorID   string      `json:"moderator_id"`
-	Reason        string      `json:"reason"`
-	Proof         string      `json:"proof"`
-	IsBanActive   bool        `json:"is_ban_active"`
-	CanBeAppealed bool        `json:"can_be_appealed"`
-	Timestamp     string      `json:"timestamp"`
-	AppealReason  string      `json:"appeal_reason"`
-	AppealDate    interface{} `json:"appeal_date"`
-	RequestedBy   string      `json:"requested_by"`
-	Exists        bool        `json:"exists"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Discriminator string    `json:"discriminator"`
+	ModeratorID   string    `json:"moderator_id"`
+	Reason        string    `json:"reason"`
+	Proof         string    `json:"proof"`
+	IsBanActive   bool      `json:"is_ban_active"`
+	CanBeAppealed bool      `json:"can_be_appealed"`
+	Timestamp     Timestamp `json:"timestamp"`
+	AppealReason  string    `json:"appeal_reason"`
+	AppealDate    Timestamp `json:"appeal_date"`
+	RequestedBy   string    `json:"requested_by"`
+	Exists        bool      `json:"exists"`
+}
+
+type BanEntry struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Discriminator string    `json:"discriminator"`
+	ModeratorID   string    `json:"moderator_id"`
+	Reason        string    `json:"reason"`
+	Proof         string    `json:"proof"`
+	IsBanActive   bool      `json:"is_ban_active"`
+	CanBeAppealed bool      `json:"can_be_appealed"`
+	Timestamp     Timestamp `json:"timestamp"`
+	AppealReason  string    `json:"appeal_reason"`
+	AppealDate    Timestamp `json:"appeal_date"`
 }
 
 type BansList struct {
@@ -106,19 +131,7 @@ type BansList struct {
 	OnPage       int         `json:"on_page"`
 	NextPage     int         `json:"next_page"`
 	PreviousPage interface{} `json:"previous_page"`
-	Data         []struct {
-		ID            string      `json:"id"`
-		Name          string      `json:"name"`
-		Discriminator string      `json:"discriminator"`
-		ModeratorID   string      `json:"moderator_id"`
-		Reason        string      `json:"reason"`
-		Proof         string      `json:"proof"`
-		IsBanActive   bool        `json:"is_ban_active"`
-		CanBeAppealed bool        `json:"can_be_appealed"`
-		Timestamp     string      `json:"timestamp"`
-		AppealReason  interface{} `json:"appeal_reason"`
-		AppealDate    interface{} `json:"appeal_date"`
-	} `json:"data"`
+	Data         []BanEntry  `json:"data"`
 }
 
 type Currency struct {
@@ -126,130 +139,137 @@ type Currency struct {
 	Pretty string  `json:"pretty"`
 }
 
+type GeoIPAPIs struct {
+	Weather       string `json:"weather"`
+	Gis           string `json:"gis"`
+	Openstreetmap string `json:"openstreetmap"`
+	Googlemaps    string `json:"googlemaps"`
+}
+
+type GeoIPData struct {
+	City          string      `json:"city"`
+	ContinentCode string      `json:"continent_code"`
+	ContinentName string      `json:"continent_name"`
+	CountryCode   string      `json:"country_code"`
+	CountryName   string      `json:"country_name"`
+	DmaCode       interface{} `json:"dma_code"`
+	Latitude      float64     `json:"latitude"`
+	Longitude     float64     `json:"longitude"`
+	PostalCode    string      `json:"postal_code"`
+	Region        string      `json:"region"`
+	TimeZone      string      `json:"time_zone"`
+	Apis          GeoIPAPIs   `json:"apis"`
+}
+
 type GeoIP struct {
-	Error bool `json:"error"`
-	Code  int  `json:"code"`
-	Data  struct {
-		City          string      `json:"city"`
-		ContinentCode string      `json:"continent_code"`
-		ContinentName string      `json:"continent_name"`
-		CountryCode   string      `json:"country_code"`
-		CountryName   string      `json:"country_name"`
-		DmaCode       interface{} `json:"dma_code"`
-		Latitude      float64     `json:"latitude"`
-		Longitude     float64     `json:"longitude"`
-		PostalCode    string      `json:"postal_code"`
-		Region        string      `json:"region"`
-		TimeZone      string      `json:"time_zone"`
-		Apis          struct {
-			Weather       string `json:"weather"`
-			Gis           string `json:"gis"`
-			Openstreetmap string `json:"openstreetmap"`
-			Googlemaps    string `json:"googlemaps"`
-		} `json:"apis"`
-	} `json:"data"`
+	Error bool      `json:"error"`
+	Code  int       `json:"code"`
+	Data  GeoIPData `json:"data"`
+}
+
+type GISData struct {
+	Address     string      `json:"address"`
+	Lat         float64     `json:"lat"`
+	Lon         float64     `json:"lon"`
+	BoundingBox BoundingBox `json:"bounding_box"`
+	Type        []string    `json:"type"`
+	Map         string      `json:"map"`
 }
 
 type GIS struct {
-	Error bool `json:"error"`
-	Code  int  `json:"code"`
-	Data  struct {
-		Address     string   `json:"address"`
-		Lat         float64  `json:"lat"`
-		Lon         float64  `json:"lon"`
-		BoundingBox []string `json:"bounding_box"`
-		Type        []string `json:"type"`
-		Map         string   `json:"map"`
-	} `json:"data"`
+	Error bool    `json:"error"`
+	Code  int     `json:"code"`
+	Data  GISData `json:"data"`
+}
+
+type LyricsHit struct {
+	Artist      string   `json:"artist"`
+	ArtistID    ArtistID `json:"artist_id"`
+	Album       string   `json:"album"`
+	AlbumIDs    AlbumIDs `json:"album_ids"`
+	AlbumYear   int      `json:"album_year"`
+	Name        string   `json:"name"`
+	Lyrics      string   `json:"lyrics"`
+	SearchStr   string   `json:"search_str"`
+	AlbumArt    string   `json:"album_art"`
+	Popularity  int      `json:"popularity"`
+	ID          TrackID  `json:"id"`
+	SearchScore float64  `json:"search_score"`
+	URL         string   `json:"url"`
 }
 
 type LyricsSearch struct {
-	Total int `json:"total"`
-	Took  int `json:"took"`
-	Data  []struct {
-		Artist      string  `json:"artist"`
-		ArtistID    int     `json:"artist_id"`
-		Album       string  `json:"album"`
-		AlbumIds    string  `json:"album_ids"`
-		AlbumYear   string  `json:"album_year"`
-		Name        string  `json:"name"`
-		Lyrics      string  `json:"lyrics"`
-		SearchStr   string  `json:"search_str"`
-		AlbumArt    string  `json:"album_art"`
-		Popularity  int     `json:"popularity"`
-		ID          string  `json:"id"`
-		SearchScore float64 `json:"search_score"`
-		URL         string  `json:"url"`
-	} `json:"data"`
+	Total int         `json:"total"`
+	Took  int         `json:"took"`
+	Data  []LyricsHit `json:"data"`
 }
 
 type Reddit struct {
-	Title     string  `json:"title"`
-	ImageURL  string  `json:"image_url"`
-	Source    string  `json:"source"`
-	Subreddit string  `json:"subreddit"`
-	Upvotes   int     `json:"upvotes"`
-	Downvotes int     `json:"downvotes"`
-	Comments  int     `json:"comments"`
-	CreatedAt float64 `json:"created_at"`
-	NSFW      bool    `json:"nsfw"`
-	Author    string  `json:"author"`
+	Title     string    `json:"title"`
+	ImageURL  string    `json:"image_url"`
+	Source    string    `json:"source"`
+	Subreddit string    `json:"subreddit"`
+	Upvotes   int       `json:"upvotes"`
+	Downvotes int       `json:"downvotes"`
+	Comments  int       `json:"comments"`
+	CreatedAt Timestamp `json:"created_at"`
+	NSFW      bool      `json:"nsfw"`
+	Author    string    `json:"author"`
 }
 
 type Track struct {
-	Name   string `json:"name"`
-	Artist struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} `json:"artist"`
-	Albums []struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-		Year int    `json:"year"`
-	} `json:"albums"`
-	Lyrics string `json:"lyrics"`
+	Name   string     `json:"name"`
+	Artist ArtistRef  `json:"artist"`
+	Albums []AlbumRef `json:"albums"`
+	Lyrics string     `json:"lyrics"`
+}
+
+type WeatherAlert struct {
+	Title       string    `json:"title"`
+	Regions     []string  `json:"regions"`
+	Severity    string    `json:"severity"`
+	Time        Timestamp `json:"time"`
+	Expires     Timestamp `json:"expires"`
+	Description string    `json:"description"`
+	URI         string    `json:"uri"`
+}
+
+type WeatherLocation struct {
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	Address string  `json:"address,omitempty"`
+}
+
+type WeatherData struct {
+	Time                Timestamp       `json:"time"`
+	Summary             string          `json:"summary"`
+	Icon                string          `json:"icon"`
+	PrecipIntensity     float64         `json:"precipIntensity"`
+	PrecipProbability   float64         `json:"precipProbability"`
+	Temperature         float64         `json:"temperature"`
+	ApparentTemperature float64         `json:"apparentTemperature"`
+	DewPoint            float64         `json:"dewPoint"`
+	Humidity            float64         `json:"humidity"`
+	Pressure            float64         `json:"pressure"`
+	WindSpeed           float64         `json:"windSpeed"`
+	WindGust            float64         `json:"windGust"`
+	WindBearing         int             `json:"windBearing"`
+	CloudCover          float64         `json:"cloudCover"`
+	UvIndex             int             `json:"uvIndex"`
+	Visibility          float64         `json:"visibility"`
+	Ozone               float64         `json:"ozone"`
+	SunriseTime         Timestamp       `json:"sunriseTime"`
+	SunsetTime          Timestamp       `json:"sunsetTime"`
+	IconURL             string          `json:"icon_url"`
+	Alerts              []WeatherAlert  `json:"alerts"`
+	Units               string          `json:"units"`
+	Location            WeatherLocation `json:"location"`
 }
 
 type Weather struct {
-	Error  bool `json:"error"`
-	Status int  `json:"status"`
-	Data   struct {
-		Time                string      `json:"time"`
-		Summary             string      `json:"summary"`
-		Icon                string      `json:"icon"`
-		PrecipIntensity     float64     `json:"precipIntensity"`
-		PrecipProbability   float64     `json:"precipProbability"`
-		Temperature         float64     `json:"temperature"`
-		ApparentTemperature float64     `json:"apparentTemperature"`
-		DewPoint            float64     `json:"dewPoint"`
-		Humidity            float64     `json:"humidity"`
-		Pressure            float64     `json:"pressure"`
-		WindSpeed           float64     `json:"windSpeed"`
-		WindGust            float64     `json:"windGust"`
-		WindBearing         int         `json:"windBearing"`
-		CloudCover          float64     `json:"cloudCover"`
-		UvIndex             int         `json:"uvIndex"`
-		Visibility          float64     `json:"visibility"`
-		Ozone               float64     `json:"ozone"`
-		SunriseTime         interface{} `json:"sunriseTime"`
-		SunsetTime          interface{} `json:"sunsetTime"`
-		IconURL             string      `json:"icon_url"`
-		Alerts              []struct {
-			Title       string   `json:"title"`
-			Regions     []string `json:"regions"`
-			Severity    string   `json:"severity"`
-			Time        int      `json:"time"`
-			Expires     int      `json:"expires"`
-			Description string   `json:"description"`
-			URI         string   `json:"uri"`
-		} `json:"alerts"`
-		Units    string `json:"units"`
-		Location struct {
-			Lat     float64 `json:"lat"`
-			Lon     float64 `json:"lon"`
-			Address string  `json:"address,omitempty"`
-		} `json:"location"`
-	} `json:"data"`
+	Error  bool        `json:"error"`
+	Status int         `json:"status"`
+	Data   WeatherData `json:"data"`
 }
 
 // POST PARAMETERS
@@ -261,17 +281,31 @@ type ParamAddBan struct {
 	Name          string `json:"user_name,omitempty"`
 	Discriminator int    `json:"user_discriminator,omitempty"`
 	ModeratorID   int64  `json:"mod,omitempty"`
-	CanBeAppealed bool   `json:"appeal_possible,omitempty"`
+	CanBeAppealed *bool  `json:"appeal_possible,omitempty"`
 }
 
+// OPTIONAL VALUES
+//
+// Parameters the API has a server-side default for are pointers, so leaving
+// them nil uses the default while an explicit false or 0 is still sent.
+
+// Bool returns a pointer to v, for optional boolean parameters.
+func Bool(v bool) *bool { return &v }
+
+// Int returns a pointer to v, for optional integer parameters.
+func Int(v int) *int { return &v }
+
+// Int64 returns a pointer to v, for optional integer parameters.
+func Int64(v int64) *int64 { return &v }
+
 // QUERY PARAMETERS
 
 type ParamRandomNSFW struct {
-	GIFsOnly bool `url:"gifs,omitempty"`
+	GIFsOnly *bool `url:"gifs,omitempty"`
 }
 
 type ParamWikiHow struct {
-	NSFW bool `url:"nsfw,omitempty"`
+	NSFW *bool `url:"nsfw,omitempty"`
 }
 
 type ParamRandomReddit struct {
@@ -280,13 +314,13 @@ type ParamRandomReddit struct {
 }
 
 type OptionalRandomReddit struct {
-	RemoveNSFW bool   `url:"remove_nsfw,omitempty"`
-	Span       string `url:"span,omitempty"`
+	RemoveNSFW *bool `url:"remove_nsfw,omitempty"`
+	Span       Span  `url:"span,omitempty"`
 }
 
 type ParamRandomImage struct {
 	Tag  string `url:"tag"`
-	NSFW bool   `url:"nsfw,omitempty"`
+	NSFW *bool  `url:"nsfw,omitempty"`
 }
 
 type ParamBans struct {
@@ -295,12 +329,12 @@ type ParamBans struct {
 
 type ParamDeleteBan struct {
 	User  int64 `url:"user"`
-	Force bool  `url:"force,omitempty"`
+	Force *bool `url:"force,omitempty"`
 }
 
 type ParamListBans struct {
-	Page    int64 `url:"page,omitempty"`
-	PerPage int   `url:"per_page,omitempty"`
+	Page    *int64 `url:"page,omitempty"`
+	PerPage *int   `url:"per_page,omitempty"`
 }
 
 type ParamAdvWeather struct {
@@ -321,32 +355,32 @@ type ParamIP struct {
 }
 
 type ParamCurrency struct {
-	CurrencyFrom string  `url:"from"`
-	CurrencyTo   string  `url:"to"`
-	Value        float64 `url:"value"`
+	CurrencyFrom CurrencyCode `url:"from"`
+	CurrencyTo   CurrencyCode `url:"to"`
+	Value        float64      `url:"value"`
 }
 
 type ParamGIS struct {
 	Location   string `url:"q"`
-	Fast       bool   `url:"fast,omitempty"`
-	More       bool   `url:"more,omitempty"`
-	MapZoom    int    `url:"map_zoom,omitempty"`
-	IncludeMap bool   `url:"include_map,omitempty"`
+	Fast       *bool  `url:"fast,omitempty"`
+	More       *bool  `url:"more,omitempty"`
+	MapZoom    *int   `url:"map_zoom,omitempty"`
+	IncludeMap *bool  `url:"include_map,omitempty"`
 }
 
 type ParamSearchLyrics struct {
 	Query    string `url:"q"`
-	TextOnly bool   `url:"text_only,omitempty"`
-	Limit    int    `url:"limit,omitempty"`
+	TextOnly *bool  `url:"text_only,omitempty"`
+	Limit    *int   `url:"limit,omitempty"`
 }
 
 // TODO: Separate report type and
 type ParamWeather struct {
-	Location   string
-	ReportType string
-	Units      string
-	Lang       string
-	Icons      string
+	Location   string `url:"q"`
+	ReportType string `url:"-"`
+	Units      string `url:"units,omitempty"`
+	Lang       string `url:"lang,omitempty"`
+	Icons      string `url:"icons,omitempty"`
 }
 
 const (
@@ -0,0 +1,307 @@
+package ksoftgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNSFWTag is returned when an NSFW tag is requested without NSFW enabled.
+var ErrNSFWTag = errors.New("tag is NSFW but NSFW was not requested")
+
+const (
+	defaultTagRefresh     = time.Hour
+	defaultTagSuggestions = 3
+	tagRetryBackoff       = time.Minute
+)
+
+// UnknownTagError is returned for a tag that is not in the catalog, with the
+// closest known tags as suggestions.
+type UnknownTagError struct {
+	Tag         string
+	Suggestions []string
+}
+
+func (e *UnknownTagError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("unknown tag %q", e.Tag)
+	}
+	return fmt.Sprintf("unknown tag %q, did you mean %s?", e.Tag, strings.Join(e.Suggestions, ", "))
+}
+
+// TagCatalog caches the tags returned by GetTags to validate RandomImage
+// parameters before they are sent. Run reloads the tags in the background
+// every RefreshInterval; without it they are reloaded on use once they are
+// older than RefreshInterval. If a reload fails the previous tags keep being
+// served, and the API is not asked again for a minute.
+type TagCatalog struct {
+	Session *KSession
+
+	// RefreshInterval is how long loaded tags are trusted.
+	RefreshInterval time.Duration
+	// OnError, if set, is called when a background reload in Run fails.
+	OnError func(err error)
+
+	refreshMu sync.Mutex
+
+	mu      sync.RWMutex
+	loaded  time.Time
+	failed  time.Time
+	failErr error
+	tags    Tags
+	sfw     map[string]struct{}
+	nsfw    map[string]struct{}
+}
+
+// NewTagCatalog creates a tag catalog refreshing every interval.
+func NewTagCatalog(s *KSession, interval time.Duration) *TagCatalog {
+	if interval <= 0 {
+		interval = defaultTagRefresh
+	}
+	return &TagCatalog{Session: s, RefreshInterval: interval}
+}
+
+// Refresh reloads the tags from the API.
+func (c *TagCatalog) Refresh() error {
+	tags, err := c.Session.GetTags()
+	if err != nil {
+		c.mu.Lock()
+		c.failed, c.failErr = time.Now(), err
+		c.mu.Unlock()
+		return err
+	}
+
+	sfw := make(map[string]struct{}, len(tags.Tags))
+	for _, t := range tags.Tags {
+		sfw[strings.ToLower(t)] = struct{}{}
+	}
+	nsfw := make(map[string]struct{}, len(tags.NsfwTags))
+	for _, t := range tags.NsfwTags {
+		nsfw[strings.ToLower(t)] = struct{}{}
+	}
+
+	c.mu.Lock()
+	c.tags, c.sfw, c.nsfw, c.loaded = tags, sfw, nsfw, time.Now()
+	c.failed, c.failErr = time.Time{}, nil
+	c.mu.Unlock()
+	return nil
+}
+
+// Run reloads the tags every RefreshInterval until ctx is cancelled, so
+// callers never wait on a reload once the first one succeeded.
+func (c *TagCatalog) Run(ctx context.Context) error {
+	interval := c.RefreshInterval
+	if interval <= 0 {
+		interval = defaultTagRefresh
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.refreshMu.Lock()
+		err := c.Refresh()
+		c.refreshMu.Unlock()
+		if err != nil && c.OnError != nil {
+			c.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tags returns the cached tags, loading them first if they are stale.
+func (c *TagCatalog) Tags() (tags Tags, err error) {
+	if err = c.ensure(); err != nil {
+		return
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tags, nil
+}
+
+// Validate checks that the tag of param exists and that NSFW tags are only
+// requested with NSFW enabled. Unknown tags return an *UnknownTagError.
+func (c *TagCatalog) Validate(param ParamRandomImage) error {
+	if err := c.ensure(); err != nil {
+		return err
+	}
+
+	tag := strings.ToLower(strings.TrimSpace(param.Tag))
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if _, ok := c.sfw[tag]; ok {
+		return nil
+	}
+	if _, ok := c.nsfw[tag]; ok {
+		if param.NSFW == nil || !*param.NSFW {
+			return ErrNSFWTag
+		}
+		return nil
+	}
+	return &UnknownTagError{Tag: param.Tag, Suggestions: c.suggest(tag, defaultTagSuggestions)}
+}
+
+// Suggest returns up to n known tags closest to tag, best match first.
+func (c *TagCatalog) Suggest(tag string, n int) ([]string, error) {
+	if err := c.ensure(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.suggest(strings.ToLower(strings.TrimSpace(tag)), n), nil
+}
+
+// RandomImage validates param against the catalog before requesting a
+// random image.
+func (c *TagCatalog) RandomImage(param ParamRandomImage) (i Image, err error) {
+	if err = c.Validate(param); err != nil {
+		return
+	}
+	return c.Session.RandomImage(param)
+}
+
+// ensure reloads stale tags, one caller at a time and at most once per
+// tagRetryBackoff after a failure. Only the first load has to succeed:
+// afterwards callers that find a reload in progress or failing keep using
+// the stale tags.
+func (c *TagCatalog) ensure() error {
+	st := c.state()
+	if st.fresh || st.loaded && st.backoff {
+		return nil
+	}
+	if st.backoff {
+		return st.err
+	}
+
+	if st.loaded {
+		if !c.refreshMu.TryLock() {
+			return nil
+		}
+	} else {
+		c.refreshMu.Lock()
+	}
+	defer c.refreshMu.Unlock()
+
+	// Another caller may have reloaded the tags, or failed to, while we
+	// waited.
+	if st = c.state(); st.fresh || st.loaded && st.backoff {
+		return nil
+	}
+	if st.backoff {
+		return st.err
+	}
+
+	err := c.Refresh()
+	if err != nil && st.loaded {
+		if c.Session.Debug {
+			c.Session.log(1, "refreshing tags failed, serving stale tags: %s", err)
+		}
+		return nil
+	}
+	return err
+}
+
+type tagState struct {
+	fresh, loaded, backoff bool
+	err                    error
+}
+
+func (c *TagCatalog) state() tagState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	loaded := !c.loaded.IsZero()
+	return tagState{
+		fresh:   loaded && time.Since(c.loaded) < c.RefreshInterval,
+		loaded:  loaded,
+		backoff: !c.failed.IsZero() && time.Since(c.failed) < tagRetryBackoff,
+		err:     c.failErr,
+	}
+}
+
+func (c *TagCatalog) suggest(tag string, n int) []string {
+	type candidate struct {
+		tag  string
+		dist int
+	}
+
+	// Typos only get suggestions within about a third of the tag length.
+	limit := len(tag)/3 + 1
+	var candidates []candidate
+	add := func(set map[string]struct{}) {
+		for t := range set {
+			d := levenshtein(tag, t)
+			if strings.HasPrefix(t, tag) || strings.HasPrefix(tag, t) {
+				d = 1
+			}
+			if d <= limit {
+				candidates = append(candidates, candidate{t, d})
+			}
+		}
+	}
+	add(c.sfw)
+	if !c.Session.strict() {
+		add(c.nsfw)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].tag < candidates[j].tag
+	})
+
+	var suggestions []string
+	for _, cand := range candidates {
+		if len(suggestions) == n {
+			break
+		}
+		suggestions = append(suggestions, cand.tag)
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,89 @@
+package ksoftgo
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testTags = `{"models":[],"tags":["dog","cat","birb"],"nsfw_tags":["hentai"]}`
+
+func TestTagCatalogValidate(t *testing.T) {
+	var requests int32
+	c := NewTagCatalog(testSession(countingResponder(http.StatusOK, testTags, &requests)), time.Hour)
+
+	tests := []struct {
+		param ParamRandomImage
+		err   error
+	}{
+		{ParamRandomImage{Tag: "dog"}, nil},
+		{ParamRandomImage{Tag: " Cat "}, nil},
+		{ParamRandomImage{Tag: "hentai"}, ErrNSFWTag},
+		{ParamRandomImage{Tag: "hentai", NSFW: Bool(true)}, nil},
+	}
+	for _, tt := range tests {
+		if err := c.Validate(tt.param); !errors.Is(err, tt.err) {
+			t.Errorf("Validate(%q) = %v, want %v", tt.param.Tag, err, tt.err)
+		}
+	}
+
+	var unknown *UnknownTagError
+	if err := c.Validate(ParamRandomImage{Tag: "dgo"}); !errors.As(err, &unknown) {
+		t.Fatalf("Validate(dgo) = %v, want *UnknownTagError", err)
+	}
+	if len(unknown.Suggestions) == 0 || unknown.Suggestions[0] != "dog" {
+		t.Errorf("suggestions for dgo = %q, want dog first", unknown.Suggestions)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+}
+
+func TestTagCatalogStaleOnError(t *testing.T) {
+	var requests int32
+	failing := int32(0)
+	s := testSession(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			return jsonResponse(http.StatusInternalServerError, `{"error":true}`), nil
+		}
+		return jsonResponse(http.StatusOK, testTags), nil
+	})
+	c := NewTagCatalog(s, time.Hour)
+
+	if err := c.Validate(ParamRandomImage{Tag: "dog"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Expire the tags and let every reload fail.
+	atomic.StoreInt32(&failing, 1)
+	c.mu.Lock()
+	c.loaded = time.Now().Add(-2 * time.Hour)
+	c.mu.Unlock()
+
+	for i := 0; i < 10; i++ {
+		if err := c.Validate(ParamRandomImage{Tag: "dog"}); err != nil {
+			t.Fatalf("Validate with stale tags: %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("made %d requests, want 2: the load and one failed reload", n)
+	}
+}
+
+func TestTagCatalogFirstLoadError(t *testing.T) {
+	var requests int32
+	c := NewTagCatalog(testSession(countingResponder(http.StatusInternalServerError, `{"error":true}`, &requests)), time.Hour)
+
+	for i := 0; i < 3; i++ {
+		var restErr *RESTError
+		if err := c.Validate(ParamRandomImage{Tag: "dog"}); !errors.As(err, &restErr) {
+			t.Fatalf("Validate without tags = %v, want *RESTError", err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+}
@@ -0,0 +1,90 @@
+package ksoftgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a point in time as returned by the API. Depending on the
+// endpoint it arrives as unix seconds (integer or float), an ISO 8601 string
+// or null, all of which decode into the embedded time.Time. A null or empty
+// value leaves the zero time, check it with IsZero.
+type Timestamp struct {
+	time.Time
+}
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if b[0] != '"' {
+		sec, err := strconv.ParseFloat(string(b), 64)
+		if err != nil {
+			return fmt.Errorf("ksoftgo: invalid timestamp %s", b)
+		}
+		t.Time = unixFloat(sec)
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	parsed, err := ParseTimestamp(str)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
+
+// ParseTimestamp parses the string forms a Timestamp accepts: unix seconds
+// and the ISO 8601 variants used by the API. Strings without a zone are UTC.
+func ParseTimestamp(str string) (t Timestamp, err error) {
+	if str == "" {
+		return
+	}
+
+	if sec, perr := strconv.ParseFloat(str, 64); perr == nil {
+		t.Time = unixFloat(sec)
+		return
+	}
+
+	for _, layout := range timestampLayouts {
+		var parsed time.Time
+		parsed, err = time.Parse(layout, str)
+		if err == nil {
+			t.Time = parsed
+			return
+		}
+	}
+	err = fmt.Errorf("ksoftgo: invalid timestamp %q", str)
+	return
+}
+
+func unixFloat(sec float64) time.Time {
+	whole, frac := math.Modf(sec)
+	return time.Unix(int64(whole), int64(frac*1e9)).UTC()
+}
@@ -0,0 +1,49 @@
+package ksoftgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshalJSON(t *testing.T) {
+	base := time.Date(2020, time.January, 1, 12, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		json string
+		want time.Time
+	}{
+		{"integer", `1577881845`, base},
+		{"float", `1577881845.5`, base.Add(500 * time.Millisecond)},
+		{"numeric string", `"1577881845"`, base},
+		{"float string", `"1577881845.25"`, base.Add(250 * time.Millisecond)},
+		{"rfc3339", `"2020-01-01T12:30:45Z"`, base},
+		{"rfc3339 offset", `"2020-01-01T14:30:45+02:00"`, base},
+		{"rfc3339 nano", `"2020-01-01T12:30:45.123456789Z"`, base.Add(123456789)},
+		{"no zone", `"2020-01-01T12:30:45"`, base},
+		{"space offset", `"2020-01-01 14:30:45+02:00"`, base},
+		{"space no zone", `"2020-01-01 12:30:45.5"`, base.Add(500 * time.Millisecond)},
+		{"date", `"2020-01-01"`, base.Truncate(24 * time.Hour)},
+		{"null", `null`, time.Time{}},
+		{"empty string", `""`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := Timestamp{time.Now()}
+			if err := ts.UnmarshalJSON([]byte(tt.json)); err != nil {
+				t.Fatalf("UnmarshalJSON(%s): %v", tt.json, err)
+			}
+			if !ts.Equal(tt.want) || ts.IsZero() != tt.want.IsZero() {
+				t.Errorf("UnmarshalJSON(%s) = %v, want %v", tt.json, ts.Time, tt.want)
+			}
+		})
+	}
+
+	for _, invalid := range []string{`"yesterday"`, `true`, `{}`, `"2020-13-01"`} {
+		var ts Timestamp
+		if err := ts.UnmarshalJSON([]byte(invalid)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) = %v, want error", invalid, ts.Time)
+		}
+	}
+}
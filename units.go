@@ -0,0 +1,220 @@
+package ksoftgo
+
+import (
+	"math"
+	"strconv"
+)
+
+// Temperature is a temperature in degrees Celsius.
+type Temperature float64
+
+// Speed is a speed in metres per second.
+type Speed float64
+
+// Pressure is an air pressure in hectopascals.
+type Pressure float64
+
+// Distance is a distance in kilometres.
+type Distance float64
+
+const (
+	kmPerMile     = 1.609344
+	hPaPerInchHg  = 33.8638866667
+	secondsInHour = 3600
+)
+
+// NewTemperature converts a temperature reported in units.
+func NewTemperature(v float64, units string) Temperature {
+	if units == UnitsUS {
+		return Temperature((v - 32) * 5 / 9)
+	}
+	return Temperature(v)
+}
+
+// NewSpeed converts a wind speed reported in units.
+func NewSpeed(v float64, units string) Speed {
+	switch units {
+	case UnitsUS, UnitsUK:
+		return Speed(v * kmPerMile * 1000 / secondsInHour)
+	case UnitsCA:
+		return Speed(v * 1000 / secondsInHour)
+	}
+	return Speed(v)
+}
+
+// NewPressure converts an air pressure reported in units. All units systems
+// report hectopascals (millibars).
+func NewPressure(v float64, units string) Pressure {
+	return Pressure(v)
+}
+
+// NewDistance converts a visibility reported in units.
+func NewDistance(v float64, units string) Distance {
+	switch units {
+	case UnitsUS, UnitsUK:
+		return Distance(v * kmPerMile)
+	}
+	return Distance(v)
+}
+
+func (t Temperature) Celsius() float64    { return float64(t) }
+func (t Temperature) Fahrenheit() float64 { return float64(t)*9/5 + 32 }
+
+func (s Speed) MetersPerSecond() float64   { return float64(s) }
+func (s Speed) KilometersPerHour() float64 { return float64(s) * secondsInHour / 1000 }
+func (s Speed) MilesPerHour() float64      { return s.KilometersPerHour() / kmPerMile }
+
+func (p Pressure) Hectopascals() float64    { return float64(p) }
+func (p Pressure) InchesOfMercury() float64 { return float64(p) / hPaPerInchHg }
+
+func (d Distance) Kilometers() float64 { return float64(d) }
+func (d Distance) Miles() float64      { return float64(d) / kmPerMile }
+
+// Format formats the temperature in the units system, e.g. "21.5 °C".
+func (t Temperature) Format(units string) string {
+	if units == UnitsUS {
+		return formatQuantity(t.Fahrenheit(), "°F")
+	}
+	return formatQuantity(t.Celsius(), "°C")
+}
+
+// Format formats the speed in the units system, e.g. "12 km/h".
+func (s Speed) Format(units string) string {
+	switch units {
+	case UnitsUS, UnitsUK:
+		return formatQuantity(s.MilesPerHour(), "mph")
+	case UnitsCA:
+		return formatQuantity(s.KilometersPerHour(), "km/h")
+	}
+	return formatQuantity(s.MetersPerSecond(), "m/s")
+}
+
+// Format formats the pressure in the units system, e.g. "29.92 inHg".
+func (p Pressure) Format(units string) string {
+	if units == UnitsUS {
+		return strconv.FormatFloat(math.Round(p.InchesOfMercury()*100)/100, 'f', -1, 64) + " inHg"
+	}
+	return formatQuantity(p.Hectopascals(), "hPa")
+}
+
+// Format formats the distance in the units system, e.g. "10 km".
+func (d Distance) Format(units string) string {
+	switch units {
+	case UnitsUS, UnitsUK:
+		return formatQuantity(d.Miles(), "mi")
+	}
+	return formatQuantity(d.Kilometers(), "km")
+}
+
+func (t Temperature) String() string { return t.Format(UnitsSI) + " / " + t.Format(UnitsUS) }
+func (s Speed) String() string       { return s.Format(UnitsCA) + " / " + s.Format(UnitsUS) }
+func (p Pressure) String() string    { return p.Format(UnitsSI) + " / " + p.Format(UnitsUS) }
+func (d Distance) String() string    { return d.Format(UnitsSI) + " / " + d.Format(UnitsUS) }
+
+func formatQuantity(v float64, unit string) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64) + " " + unit
+}
+
+// Temp returns the temperature, converted from Data.Units.
+func (d WeatherData) Temp() Temperature {
+	return NewTemperature(d.Temperature, d.Units)
+}
+
+// ApparentTemp returns the felt temperature, converted from Data.Units.
+func (d WeatherData) ApparentTemp() Temperature {
+	return NewTemperature(d.ApparentTemperature, d.Units)
+}
+
+// DewPointTemp returns the dew point, converted from Data.Units.
+func (d WeatherData) DewPointTemp() Temperature {
+	return NewTemperature(d.DewPoint, d.Units)
+}
+
+// Wind returns the wind speed, converted from Data.Units.
+func (d WeatherData) Wind() Speed {
+	return NewSpeed(d.WindSpeed, d.Units)
+}
+
+// Gusts returns the wind gust speed, converted from Data.Units.
+func (d WeatherData) Gusts() Speed {
+	return NewSpeed(d.WindGust, d.Units)
+}
+
+// AirPressure returns the sea-level air pressure, converted from Data.Units.
+func (d WeatherData) AirPressure() Pressure {
+	return NewPressure(d.Pressure, d.Units)
+}
+
+// VisibilityRange returns the visibility, converted from Data.Units.
+func (d WeatherData) VisibilityRange() Distance {
+	return NewDistance(d.Visibility, d.Units)
+}
+
+// WindDirection returns the compass point the wind blows from.
+func (d WeatherData) WindDirection() string { return CompassDirection(d.WindBearing) }
+
+// UVCategory returns the WHO category of the UV index.
+func (d WeatherData) UVCategory() string { return UVCategory(d.UvIndex) }
+
+// Emoji returns an emoji for the weather icon.
+func (d WeatherData) Emoji() string { return IconEmoji(d.Icon) }
+
+var compassPoints = [16]string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// CompassDirection converts a bearing in degrees to one of the 16 compass
+// points, e.g. 200 to "SSW".
+func CompassDirection(bearing int) string {
+	b := ((bearing % 360) + 360) % 360
+	return compassPoints[int(math.Round(float64(b)/22.5))%16]
+}
+
+// UVCategory returns the WHO exposure category of a UV index.
+func UVCategory(index int) string {
+	switch {
+	case index <= 2:
+		return "Low"
+	case index <= 5:
+		return "Moderate"
+	case index <= 7:
+		return "High"
+	case index <= 10:
+		return "Very high"
+	}
+	return "Extreme"
+}
+
+// IconEmoji maps a weather icon to an emoji.
+func IconEmoji(icon string) string {
+	switch icon {
+	case "clear-day":
+		return "☀️"
+	case "clear-night":
+		return "🌙"
+	case "rain":
+		return "🌧️"
+	case "snow":
+		return "❄️"
+	case "sleet":
+		return "🌨️"
+	case "wind":
+		return "💨"
+	case "fog":
+		return "🌫️"
+	case "cloudy":
+		return "☁️"
+	case "partly-cloudy-day":
+		return "⛅"
+	case "partly-cloudy-night":
+		return "☁️"
+	case "hail":
+		return "🧊"
+	case "thunderstorm":
+		return "⛈️"
+	case "tornado":
+		return "🌪️"
+	}
+	return "🌡️"
+}
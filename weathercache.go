@@ -0,0 +1,375 @@
+package ksoftgo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrReportType is returned when a WeatherCache method is asked for a report
+// type it does not cache.
+var ErrReportType = errors.New("report type not served by this method")
+
+const (
+	defaultWeatherGrid    = 0.05
+	defaultWeatherEntries = 1000
+	defaultGISTTL         = 24 * time.Hour
+)
+
+// DefaultWeatherTTL is how long a cached report of each type stays fresh.
+var DefaultWeatherTTL = map[string]time.Duration{
+	ReportCurrently: 5 * time.Minute,
+	ReportMinutely:  time.Minute,
+	ReportHourly:    15 * time.Minute,
+	ReportDaily:     time.Hour,
+}
+
+// WeatherCache caches weather reports per grid cell of rounded coordinates,
+// so requests from nearby places share one API call. Location names are
+// resolved to coordinates through a cached GetGIS.
+type WeatherCache struct {
+	Session *KSession
+
+	// GridSize is the size of a grid cell in degrees; 0.05 is about 5 km.
+	GridSize float64
+	// TTL overrides DefaultWeatherTTL per report type.
+	TTL map[string]time.Duration
+	// GISTTL is how long resolved location names are kept.
+	GISTTL time.Duration
+	// MaxEntries bounds the number of cached reports, and separately the
+	// number of resolved location names.
+	MaxEntries int
+
+	mu      sync.Mutex
+	reports map[weatherKey]weatherEntry
+	places  map[string]placeEntry
+}
+
+type weatherKey struct {
+	lat, lon      int64
+	report, units string
+	lang, icons   string
+}
+
+type weatherEntry struct {
+	report  interface{}
+	expires time.Time
+}
+
+type placeEntry struct {
+	gis     GIS
+	expires time.Time
+}
+
+// NewWeatherCache creates a weather cache with the default grid and TTLs.
+func NewWeatherCache(s *KSession) *WeatherCache {
+	return &WeatherCache{
+		Session:    s,
+		GridSize:   defaultWeatherGrid,
+		GISTTL:     defaultGISTTL,
+		MaxEntries: defaultWeatherEntries,
+		reports:    make(map[weatherKey]weatherEntry),
+		places:     make(map[string]placeEntry),
+	}
+}
+
+// GetAdvWeather returns the current weather of the grid cell around the
+// coordinates, from the cache while it is fresh. The report type of params
+// must be empty or ReportCurrently; the other reports are cached by
+// MinutelyForecastAdv, HourlyForecastAdv and DailyForecastAdv.
+func (c *WeatherCache) GetAdvWeather(params ParamAdvWeather) (weather Weather, err error) {
+	if err = checkCurrently(params.ReportType); err != nil {
+		return
+	}
+	params.ReportType = ReportCurrently
+	v, err := c.lookup(params, func(p ParamAdvWeather) (interface{}, error) {
+		return c.Session.GetAdvWeather(p)
+	})
+	if err == nil {
+		weather = v.(Weather)
+	}
+	return
+}
+
+// MinutelyForecastAdv returns the minutely forecast of the grid cell around
+// the coordinates, from the cache while it is fresh.
+func (c *WeatherCache) MinutelyForecastAdv(params ParamAdvWeather) (forecast MinutelyWeather, err error) {
+	params.ReportType = ReportMinutely
+	v, err := c.lookup(params, func(p ParamAdvWeather) (interface{}, error) {
+		return c.Session.MinutelyForecastAdv(p)
+	})
+	if err == nil {
+		forecast = v.(MinutelyWeather)
+	}
+	return
+}
+
+// HourlyForecastAdv returns the hourly forecast of the grid cell around the
+// coordinates, from the cache while it is fresh.
+func (c *WeatherCache) HourlyForecastAdv(params ParamAdvWeather) (forecast HourlyWeather, err error) {
+	params.ReportType = ReportHourly
+	v, err := c.lookup(params, func(p ParamAdvWeather) (interface{}, error) {
+		return c.Session.HourlyForecastAdv(p)
+	})
+	if err == nil {
+		forecast = v.(HourlyWeather)
+	}
+	return
+}
+
+// DailyForecastAdv returns the daily forecast of the grid cell around the
+// coordinates, from the cache while it is fresh.
+func (c *WeatherCache) DailyForecastAdv(params ParamAdvWeather) (forecast DailyWeather, err error) {
+	params.ReportType = ReportDaily
+	v, err := c.lookup(params, func(p ParamAdvWeather) (interface{}, error) {
+		return c.Session.DailyForecastAdv(p)
+	})
+	if err == nil {
+		forecast = v.(DailyWeather)
+	}
+	return
+}
+
+// GetWeather resolves the location name through the GIS cache and returns
+// the cached current weather of its grid cell. Like GetAdvWeather it only
+// serves ReportCurrently.
+func (c *WeatherCache) GetWeather(params ParamWeather) (weather Weather, err error) {
+	if err = checkCurrently(params.ReportType); err != nil {
+		return
+	}
+	adv, address, err := c.locate(params)
+	if err != nil {
+		return
+	}
+
+	weather, err = c.GetAdvWeather(adv)
+	if err == nil && weather.Data.Location.Address == "" {
+		weather.Data.Location.Address = address
+	}
+	return
+}
+
+// MinutelyForecast resolves the location name through the GIS cache and
+// returns the cached minutely forecast of its grid cell.
+func (c *WeatherCache) MinutelyForecast(params ParamWeather) (forecast MinutelyWeather, err error) {
+	adv, address, err := c.locate(params)
+	if err != nil {
+		return
+	}
+
+	forecast, err = c.MinutelyForecastAdv(adv)
+	if err == nil && forecast.Data.Location.Address == "" {
+		forecast.Data.Location.Address = address
+	}
+	return
+}
+
+// HourlyForecast resolves the location name through the GIS cache and
+// returns the cached hourly forecast of its grid cell.
+func (c *WeatherCache) HourlyForecast(params ParamWeather) (forecast HourlyWeather, err error) {
+	adv, address, err := c.locate(params)
+	if err != nil {
+		return
+	}
+
+	forecast, err = c.HourlyForecastAdv(adv)
+	if err == nil && forecast.Data.Location.Address == "" {
+		forecast.Data.Location.Address = address
+	}
+	return
+}
+
+// DailyForecast resolves the location name through the GIS cache and returns
+// the cached daily forecast of its grid cell.
+func (c *WeatherCache) DailyForecast(params ParamWeather) (forecast DailyWeather, err error) {
+	adv, address, err := c.locate(params)
+	if err != nil {
+		return
+	}
+
+	forecast, err = c.DailyForecastAdv(adv)
+	if err == nil && forecast.Data.Location.Address == "" {
+		forecast.Data.Location.Address = address
+	}
+	return
+}
+
+// GetGIS returns the cached GIS lookup of a location name.
+func (c *WeatherCache) GetGIS(params ParamGIS) (gis GIS, err error) {
+	name := strings.ToLower(strings.TrimSpace(params.Location))
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.places[name]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.gis, nil
+	}
+
+	gis, err = c.Session.GetGIS(params)
+	if err != nil {
+		return
+	}
+
+	ttl := c.GISTTL
+	if ttl <= 0 {
+		ttl = defaultGISTTL
+	}
+	c.mu.Lock()
+	if c.places == nil {
+		c.places = make(map[string]placeEntry)
+	}
+	c.places[name] = placeEntry{gis: gis, expires: now.Add(ttl)}
+	c.prunePlaces(now)
+	c.mu.Unlock()
+	return
+}
+
+func checkCurrently(report string) error {
+	if report != "" && report != ReportCurrently {
+		return fmt.Errorf("%w: %q, use the %s forecast methods", ErrReportType, report, report)
+	}
+	return nil
+}
+
+// lookup returns the cached report for the grid cell around the coordinates
+// of params, calling fetch with the cell's center when it is missing or
+// stale.
+func (c *WeatherCache) lookup(params ParamAdvWeather, fetch func(ParamAdvWeather) (interface{}, error)) (interface{}, error) {
+	grid := c.GridSize
+	if grid <= 0 {
+		grid = defaultWeatherGrid
+	}
+	key := weatherKey{
+		lat:    int64(math.Round(params.Latitude / grid)),
+		lon:    int64(math.Round(params.Longitude / grid)),
+		report: params.ReportType,
+		units:  params.Options.Units,
+		lang:   params.Options.Lang,
+		icons:  params.Options.Icons,
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.reports[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.report, nil
+	}
+
+	// Every coordinate in a cell gets the report of the cell's center.
+	params.Latitude = float64(key.lat) * grid
+	params.Longitude = float64(key.lon) * grid
+	report, err := fetch(params)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.reports == nil {
+		c.reports = make(map[weatherKey]weatherEntry)
+	}
+	c.reports[key] = weatherEntry{report: report, expires: now.Add(c.ttl(params.ReportType))}
+	c.prune(now)
+	c.mu.Unlock()
+	return report, nil
+}
+
+// locate resolves the location of params to coordinates through the GIS
+// cache.
+func (c *WeatherCache) locate(params ParamWeather) (adv ParamAdvWeather, address string, err error) {
+	gis, err := c.GetGIS(ParamGIS{Location: params.Location})
+	if err != nil {
+		return
+	}
+
+	adv = ParamAdvWeather{
+		Latitude:  gis.Data.Lat,
+		Longitude: gis.Data.Lon,
+		Options: OptionalAdvWeather{
+			Units: params.Units,
+			Lang:  params.Lang,
+			Icons: params.Icons,
+		},
+	}
+	return adv, gis.Data.Address, nil
+}
+
+// Purge drops every cached report and location.
+func (c *WeatherCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reports = make(map[weatherKey]weatherEntry)
+	c.places = make(map[string]placeEntry)
+}
+
+func (c *WeatherCache) ttl(report string) time.Duration {
+	if ttl, ok := c.TTL[report]; ok {
+		return ttl
+	}
+	if ttl, ok := DefaultWeatherTTL[report]; ok {
+		return ttl
+	}
+	return DefaultWeatherTTL[ReportCurrently]
+}
+
+func (c *WeatherCache) maxEntries() int {
+	if c.MaxEntries <= 0 {
+		return defaultWeatherEntries
+	}
+	return c.MaxEntries
+}
+
+// prune drops expired reports once the cache is full, then the reports
+// closest to expiry until it fits again. c.mu must be held.
+func (c *WeatherCache) prune(now time.Time) {
+	max := c.maxEntries()
+	if len(c.reports) <= max {
+		return
+	}
+
+	for key, entry := range c.reports {
+		if !now.Before(entry.expires) {
+			delete(c.reports, key)
+		}
+	}
+	for len(c.reports) > max {
+		var oldest weatherKey
+		var first time.Time
+		for key, entry := range c.reports {
+			if first.IsZero() || entry.expires.Before(first) {
+				oldest, first = key, entry.expires
+			}
+		}
+		delete(c.reports, oldest)
+	}
+}
+
+// prunePlaces bounds the resolved location names like prune bounds the
+// reports. c.mu must be held.
+func (c *WeatherCache) prunePlaces(now time.Time) {
+	max := c.maxEntries()
+	if len(c.places) <= max {
+		return
+	}
+
+	for name, entry := range c.places {
+		if !now.Before(entry.expires) {
+			delete(c.places, name)
+		}
+	}
+	for len(c.places) > max {
+		var oldest string
+		var first time.Time
+		for name, entry := range c.places {
+			if first.IsZero() || entry.expires.Before(first) {
+				oldest, first = name, entry.expires
+			}
+		}
+		delete(c.places, oldest)
+	}
+}
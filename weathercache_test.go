@@ -0,0 +1,80 @@
+package ksoftgo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWeatherCacheReportType(t *testing.T) {
+	var requests int32
+	c := NewWeatherCache(testSession(countingResponder(http.StatusOK, `{"data":{"summary":"Clear"}}`, &requests)))
+
+	for _, report := range []string{"", ReportCurrently} {
+		if _, err := c.GetAdvWeather(ParamAdvWeather{ReportType: report}); err != nil {
+			t.Errorf("GetAdvWeather(%q): %v", report, err)
+		}
+	}
+	for _, report := range []string{ReportMinutely, ReportHourly, ReportDaily} {
+		if _, err := c.GetAdvWeather(ParamAdvWeather{ReportType: report}); !errors.Is(err, ErrReportType) {
+			t.Errorf("GetAdvWeather(%q) = %v, want ErrReportType", report, err)
+		}
+		if _, err := c.GetWeather(ParamWeather{Location: "Montreal", ReportType: report}); !errors.Is(err, ErrReportType) {
+			t.Errorf("GetWeather(%q) = %v, want ErrReportType", report, err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+}
+
+func TestWeatherCacheGrid(t *testing.T) {
+	var paths []string
+	s := testSession(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		if strings.HasSuffix(req.URL.Path, "/"+ReportHourly) {
+			return jsonResponse(http.StatusOK, `{"data":{"data":[{"temperature":1},{"temperature":2}]}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":{"summary":"Clear"}}`), nil
+	})
+	c := NewWeatherCache(s)
+
+	// Both points fall into the cell around 45.5,-73.55.
+	for _, lat := range []float64{45.49, 45.51} {
+		if _, err := c.GetAdvWeather(ParamAdvWeather{Latitude: lat, Longitude: -73.56}); err != nil {
+			t.Fatal(err)
+		}
+		forecast, err := c.HourlyForecastAdv(ParamAdvWeather{Latitude: lat, Longitude: -73.56})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(forecast.Data.Data) != 2 {
+			t.Errorf("hourly forecast has %d points, want 2", len(forecast.Data.Data))
+		}
+	}
+
+	want := []string{"/kumo/weather/45.5,-73.55/currently", "/kumo/weather/45.5,-73.55/hourly"}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("requested %q, want %q", paths, want)
+	}
+}
+
+func TestWeatherCachePlacesBounded(t *testing.T) {
+	var requests int32
+	c := NewWeatherCache(testSession(countingResponder(http.StatusOK, `{"data":{"lat":1,"lon":2}}`, &requests)))
+	c.MaxEntries = 3
+
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		if _, err := c.GetGIS(ParamGIS{Location: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(c.places); n != 3 {
+		t.Errorf("%d places cached, want 3", n)
+	}
+	if n := atomic.LoadInt32(&requests); n != 6 {
+		t.Errorf("made %d requests, want 6", n)
+	}
+}